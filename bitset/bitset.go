@@ -11,6 +11,8 @@ That's a lot more compact because 10,000 bits fit in less than 1250 bytes(10,000
 */
 package bitset
 
+import "math/bits"
+
 const byteLen = 8
 
 type BitSet []byte
@@ -20,6 +22,9 @@ func New(size int) BitSet {
 	return make([]byte, realSize)
 }
 
+// Len returns the number of bits the set can hold
+func (bs BitSet) Len() int { return len(bs) * byteLen }
+
 // Set true at the index
 func (bs BitSet) Set(index int) {
 	index, mask := bs.getIndexMask(index)
@@ -38,6 +43,15 @@ func (bs BitSet) Get(index int) bool {
 	return bs[index]&mask != 0
 }
 
+// Count returns the number of bits set to true
+func (bs BitSet) Count() int {
+	res := 0
+	for _, b := range bs {
+		res += bits.OnesCount8(b)
+	}
+	return res
+}
+
 func (bs BitSet) getIndexMask(index int) (int, byte) {
 	return index / byteLen, 1 << (index % byteLen)
 }
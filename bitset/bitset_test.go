@@ -1,6 +1,15 @@
 package bitset
 
-import "testing"
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"runtime"
+	"testing"
+)
 
 func Test(t *testing.T) {
 	const total = 2019
@@ -21,6 +30,9 @@ func Test(t *testing.T) {
 	if bs.Get(8) {
 		t.Error("failed")
 	}
+	if bs.Count() != total/2 {
+		t.Errorf("expected count %d, got %d", total/2, bs.Count())
+	}
 }
 
 func gen(size int, idx ...int) BitSet {
@@ -94,6 +106,169 @@ func TestDifference(t *testing.T) {
 	}
 }
 
+func TestMarshalBinary(t *testing.T) {
+	a := gen(20, 0, 7, 18)
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != headerSize+len(a) {
+		t.Errorf("expected len %d, got %d", headerSize+len(a), len(data))
+	}
+	var b BitSet
+	if err := b.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Errorf("expected %v, got %v", a, b)
+	}
+	if b.Len() != a.Len() {
+		t.Errorf("expected bit length %d, got %d", a.Len(), b.Len())
+	}
+
+	if err := b.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("expected %v, got %v", ErrInvalidData, err)
+	}
+	data[0] = 2
+	if err := b.UnmarshalBinary(data); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected %v, got %v", ErrUnsupportedVersion, err)
+	}
+}
+
+func TestMarshalBinaryLen(t *testing.T) {
+	a := gen(20, 0, 7, 18)
+	a.Set(22) // beyond the 20 bits, left out
+	data, err := a.MarshalBinaryLen(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != headerSize+3 {
+		t.Errorf("expected len %d, got %d", headerSize+3, len(data))
+	}
+	var b BitSet
+	n, err := b.UnmarshalBinaryLen(data)
+	if err != nil || n != 20 {
+		t.Fatalf("expected bit length 20, got %d, err: %v", n, err)
+	}
+	if expected := gen(20, 0, 7, 18); !bytes.Equal(b, expected) {
+		t.Errorf("expected %v, got %v", expected, b)
+	}
+	// the standard interfaces accept it too, rounded to whole bytes
+	var c BitSet
+	if err := c.UnmarshalBinary(data); err != nil || c.Len() != 24 {
+		t.Errorf("expected bit length 24, got %d, err: %v", c.Len(), err)
+	}
+
+	if n, err := b.UnmarshalBinaryLen(must(a.MarshalBinaryLen(0))); err != nil || n != 0 || len(b) != 0 {
+		t.Errorf("expected an empty set, got %v with bit length %d, err: %v", b, n, err)
+	}
+	for _, n := range []int{-1, a.Len() + 1} {
+		if _, err := a.MarshalBinaryLen(n); err != ErrInvalidLen {
+			t.Errorf("expected %v, got %v", ErrInvalidLen, err)
+		}
+	}
+	data[len(data)-1] |= 1 << 5
+	if _, err := b.UnmarshalBinaryLen(data); err != ErrInvalidData {
+		t.Errorf("expected %v, got %v", ErrInvalidData, err)
+	}
+	if _, err := b.ReadFrom(bytes.NewReader(data)); err != ErrInvalidData {
+		t.Errorf("expected %v, got %v", ErrInvalidData, err)
+	}
+}
+
+func must(data []byte, err error) []byte {
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestWriteToReadFrom(t *testing.T) {
+	a := gen(2019, 1, 100, 2000)
+	buf := &bytes.Buffer{}
+	n, err := a.WriteTo(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(headerSize+len(a)) || n != int64(buf.Len()) {
+		t.Errorf("unexpected written size %d", n)
+	}
+	tail := gen(8, 3)
+	tail.WriteTo(buf)
+
+	var b, c BitSet
+	if m, err := b.ReadFrom(buf); err != nil || m != n {
+		t.Fatalf("read %d bytes, err: %v", m, err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Errorf("expected %v, got %v", a, b)
+	}
+	if _, err := c.ReadFrom(buf); err != nil || !bytes.Equal(tail, c) {
+		t.Errorf("expected %v, got %v, err: %v", tail, c, err)
+	}
+	if _, err := c.ReadFrom(buf); err != io.EOF {
+		t.Errorf("expected %v, got %v", io.EOF, err)
+	}
+
+	a.WriteTo(buf)
+	buf.Truncate(buf.Len() - 1)
+	if _, err := c.ReadFrom(buf); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+}
+
+func TestReadFromLarge(t *testing.T) {
+	a := New(3*chunkSize*byteLen + 20)
+	for i := 0; i < a.Len(); i += 7 {
+		a.Set(i)
+	}
+	buf := &bytes.Buffer{}
+	a.WriteTo(buf)
+	var b BitSet
+	if _, err := b.ReadFrom(buf); err != nil || !bytes.Equal(a, b) {
+		t.Errorf("decoded bitset differs, err: %v", err)
+	}
+
+	// a header claiming 2 GiB followed by a few bytes must not allocate it all up front
+	header := make([]byte, headerSize)
+	header[0] = version
+	binary.BigEndian.PutUint64(header[1:], math.MaxInt32*byteLen)
+	r := io.MultiReader(bytes.NewReader(header), bytes.NewReader(make([]byte, 10)))
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err := b.ReadFrom(r)
+	runtime.ReadMemStats(&after)
+	allocated := after.TotalAlloc - before.TotalAlloc
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("expected %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+	if allocated > 1<<20 {
+		t.Errorf("allocated %d bytes for a truncated input", allocated)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type flags struct {
+		User  string
+		Flags BitSet
+	}
+	a := flags{User: "tom", Flags: gen(30, 2, 29)}
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b flags
+	if err := json.Unmarshal(data, &b); err != nil {
+		t.Fatal(err)
+	}
+	if b.User != a.User || !bytes.Equal(a.Flags, b.Flags) {
+		t.Errorf("expected %v, got %v", a, b)
+	}
+	if err := json.Unmarshal([]byte(`{"Flags":"!!"}`), &b); err == nil {
+		t.Error("expected error for invalid text")
+	}
+}
+
 func Benchmark(b *testing.B) {
 	bs := New(b.N)
 	for i := 0; i < b.N; i++ {
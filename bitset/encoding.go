@@ -0,0 +1,172 @@
+package bitset
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"slices"
+)
+
+// The binary form is a fixed header followed by the raw bytes:
+//
+//	version (1 byte) | bit length (8 bytes, big endian) | bits
+//
+// A BitSet is a plain byte slice and does not remember the size it was created with,
+// so MarshalBinary and the other standard interfaces record Len(), the size rounded up to whole bytes.
+// MarshalBinaryLen records the logical bit length instead, and UnmarshalBinaryLen returns it:
+// New(20) round-trips as 20 bits that way. The bits follow in (length+7)/8 bytes,
+// the unused high bits of the last byte must be zero.
+const (
+	version    byte = 1
+	headerSize      = 1 + 8
+	chunkSize       = 64 << 10 // ReadFrom grows its buffer by at most this many bytes per read
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("bitset: unsupported encoding version")
+	ErrInvalidData        = errors.New("bitset: invalid encoded data")
+	ErrInvalidLen         = errors.New("bitset: bit length out of range")
+)
+
+var encoding = base64.RawURLEncoding
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (bs BitSet) MarshalBinary() ([]byte, error) {
+	return bs.MarshalBinaryLen(bs.Len())
+}
+
+// MarshalBinaryLen is like MarshalBinary for a set of n bits, such as one created by New(n):
+// it records n as the bit length, and leaves out the bytes and bits from index n on.
+// It returns ErrInvalidLen if n is negative or greater than Len().
+func (bs BitSet) MarshalBinaryLen(n int) ([]byte, error) {
+	if n < 0 || n > bs.Len() {
+		return nil, ErrInvalidLen
+	}
+	size := (n + byteLen - 1) / byteLen
+	buf := make([]byte, headerSize+size)
+	putHeader(buf, n)
+	copy(buf[headerSize:], bs[:size])
+	if r := n % byteLen; r != 0 {
+		buf[len(buf)-1] &= 1<<r - 1
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (bs *BitSet) UnmarshalBinary(data []byte) error {
+	_, err := bs.UnmarshalBinaryLen(data)
+	return err
+}
+
+// UnmarshalBinaryLen is like UnmarshalBinary, and also returns the bit length recorded in data
+func (bs *BitSet) UnmarshalBinaryLen(data []byte) (int, error) {
+	bits, size, err := parseHeader(data)
+	if err != nil {
+		return 0, err
+	}
+	if len(data)-headerSize != size {
+		return 0, ErrInvalidData
+	}
+	res := append(make(BitSet, 0, size), data[headerSize:]...)
+	if err := checkPadding(res, bits); err != nil {
+		return 0, err
+	}
+	*bs = res
+	return bits, nil
+}
+
+// WriteTo implements io.WriterTo
+func (bs BitSet) WriteTo(w io.Writer) (int64, error) {
+	var header [headerSize]byte
+	putHeader(header[:], bs.Len())
+	n, err := w.Write(header[:])
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(bs)
+	return int64(n + m), err
+}
+
+// ReadFrom implements io.ReaderFrom
+func (bs *BitSet) ReadFrom(r io.Reader) (int64, error) {
+	var header [headerSize]byte
+	n, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return int64(n), err
+	}
+	bits, size, err := parseHeader(header[:])
+	if err != nil {
+		return int64(n), err
+	}
+	// the size comes from untrusted input, so the buffer grows only as data actually arrives
+	buf := make(BitSet, 0, min(size, chunkSize))
+	for len(buf) < size {
+		k := min(size-len(buf), chunkSize)
+		buf = slices.Grow(buf, k)
+		m, err := io.ReadFull(r, buf[len(buf):len(buf)+k])
+		buf = buf[:len(buf)+m]
+		n += m
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return int64(n), err
+		}
+	}
+	if err := checkPadding(buf, bits); err != nil {
+		return int64(n), err
+	}
+	*bs = buf
+	return int64(n), nil
+}
+
+// MarshalText implements encoding.TextMarshaler,
+// the text form is the binary form in unpadded url-safe base64,
+// so a BitSet is embedded in JSON as a string.
+func (bs BitSet) MarshalText() ([]byte, error) {
+	data, _ := bs.MarshalBinary()
+	buf := make([]byte, encoding.EncodedLen(len(data)))
+	encoding.Encode(buf, data)
+	return buf, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (bs *BitSet) UnmarshalText(text []byte) error {
+	data := make([]byte, encoding.DecodedLen(len(text)))
+	n, err := encoding.Decode(data, text)
+	if err != nil {
+		return ErrInvalidData
+	}
+	return bs.UnmarshalBinary(data[:n])
+}
+
+func putHeader(buf []byte, bits int) {
+	buf[0] = version
+	binary.BigEndian.PutUint64(buf[1:headerSize], uint64(bits))
+}
+
+// parseHeader validates the header and returns the bit length and the size in bytes of the bits following it
+func parseHeader(data []byte) (bits, size int, err error) {
+	if len(data) < headerSize {
+		return 0, 0, ErrInvalidData
+	}
+	if data[0] != version {
+		return 0, 0, ErrUnsupportedVersion
+	}
+	n := binary.BigEndian.Uint64(data[1:headerSize])
+	if n > math.MaxInt32*byteLen {
+		return 0, 0, ErrInvalidData
+	}
+	bits = int(n)
+	return bits, (bits + byteLen - 1) / byteLen, nil
+}
+
+// checkPadding checks that the bits of bs from index bits on are zero
+func checkPadding(bs BitSet, bits int) error {
+	if r := bits % byteLen; r != 0 && bs[len(bs)-1]>>r != 0 {
+		return ErrInvalidData
+	}
+	return nil
+}
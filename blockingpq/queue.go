@@ -0,0 +1,209 @@
+// Package blockingpq implements a concurrent priority queue for worker pools.
+//
+// Pop blocks until an item is available, and with a limit Push blocks until there is room,
+// both giving up when their context is done. After Close, Push fails at once,
+// while Pop keeps draining the remaining items and then fails.
+package blockingpq
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/heap"
+	"github.com/zrcoder/dsgo/list"
+)
+
+// ErrClosed is returned by Push on a closed queue, and by Pop on a closed and drained one
+var ErrClosed = errors.New("blockingpq: queue closed")
+
+// Queue is a priority queue safe for concurrent use, the least item by its comparator comes first
+type Queue[T any] struct {
+	mu     sync.Mutex
+	heap   *heap.Heap[T]
+	limit  int // maximum number of items, 0 for unbounded
+	closed bool
+	// blocked Pops and Pushes wait in line, each pushed item or freed slot wakes the first one only
+	pops   *list.List[*waiter]
+	pushes *list.List[*waiter]
+}
+
+type waiter struct {
+	ready chan struct{} // closed when woken
+	woken bool
+}
+
+type Option[T any] func(q *Queue[T])
+
+// WithLimit bounds the queue to n items, Push blocks while it is full
+func WithLimit[T any](n int) Option[T] {
+	return func(q *Queue[T]) {
+		if n < 1 {
+			panic("blockingpq: limit must be at least 1")
+		}
+		q.limit = n
+	}
+}
+
+func New[T cmp.Ordered](ops ...Option[T]) *Queue[T] {
+	return NewWith[T](cmp.Compare[T], ops...)
+}
+
+func NewWith[T any](cmp dsgo.Comparator[T], ops ...Option[T]) *Queue[T] {
+	q := &Queue[T]{
+		pops:   list.New[*waiter](),
+		pushes: list.New[*waiter](),
+	}
+	for _, op := range ops {
+		op(q)
+	}
+	q.heap = heap.NewWith(cmp, heap.WithCapacity[T](q.limit))
+	return q
+}
+
+// Push adds value, blocking while the queue is full.
+// It returns ErrClosed if the queue is closed, or the context's error if ctx is done first.
+func (q *Queue[T]) Push(ctx context.Context, value T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if q.closed {
+			return ErrClosed
+		}
+		if !q.full() {
+			q.push(value)
+			return nil
+		}
+		if err := q.wait(ctx, q.pushes); err != nil {
+			return err
+		}
+	}
+}
+
+// TryPush adds value if the queue is neither full nor closed, it never blocks
+func (q *Queue[T]) TryPush(value T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.full() {
+		return false
+	}
+	q.push(value)
+	return true
+}
+
+// Pop removes and returns the least item, blocking while the queue is empty.
+// It returns ErrClosed once the queue is closed and drained, or the context's error if ctx is done first.
+func (q *Queue[T]) Pop(ctx context.Context) (value T, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if !q.heap.Empty() {
+			return q.pop(), nil
+		}
+		if q.closed {
+			return value, ErrClosed
+		}
+		if err := q.wait(ctx, q.pops); err != nil {
+			return value, err
+		}
+	}
+}
+
+// TryPop removes and returns the least item if there is one, it never blocks
+func (q *Queue[T]) TryPop() (value T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.heap.Empty() {
+		return
+	}
+	return q.pop(), true
+}
+
+// Peek returns the least item without removing it
+func (q *Queue[T]) Peek() (value T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.heap.Peek()
+}
+
+// Len returns the number of items
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.heap.Len()
+}
+
+// Close closes the queue and wakes all waiters: blocked Pushes fail,
+// blocked Pops fail as there is nothing left to drain. Closing twice is a no-op.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	for wake(q.pops) {
+	}
+	for wake(q.pushes) {
+	}
+}
+
+// Closed returns true if the queue is closed
+func (q *Queue[T]) Closed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+func (q *Queue[T]) full() bool {
+	return q.limit > 0 && q.heap.Len() >= q.limit
+}
+
+func (q *Queue[T]) push(value T) {
+	q.heap.Push(value)
+	wake(q.pops)
+}
+
+func (q *Queue[T]) pop() T {
+	value, _ := q.heap.Pop()
+	if q.limit > 0 {
+		wake(q.pushes)
+	}
+	return value
+}
+
+// wait queues up in line and blocks until woken or ctx is done, the caller must hold the lock,
+// which is released while waiting
+func (q *Queue[T]) wait(ctx context.Context, line *list.List[*waiter]) error {
+	w := &waiter{ready: make(chan struct{})}
+	e := line.PushBack(w)
+	q.mu.Unlock()
+	select {
+	case <-ctx.Done():
+		q.mu.Lock()
+		if w.woken {
+			// the wake-up was meant for someone in line, pass it on
+			wake(line)
+		} else {
+			line.Remove(e)
+		}
+		return ctx.Err()
+	case <-w.ready:
+		q.mu.Lock()
+		return nil
+	}
+}
+
+// wake wakes the first waiter in line and returns true if there was one, the caller must hold the lock
+func wake(line *list.List[*waiter]) bool {
+	e := line.Front()
+	if e == nil {
+		return false
+	}
+	w := line.Remove(e)
+	w.woken = true
+	close(w.ready)
+	return true
+}
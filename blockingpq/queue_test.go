@@ -0,0 +1,270 @@
+package blockingpq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTry(t *testing.T) {
+	q := New[int](WithLimit[int](3))
+	if _, ok := q.TryPop(); ok {
+		t.Error("expected an empty queue")
+	}
+	for _, v := range []int{5, 1, 3} {
+		if !q.TryPush(v) {
+			t.Errorf("expected %v to be pushed", v)
+		}
+	}
+	if q.TryPush(0) {
+		t.Error("expected a full queue to reject the push")
+	}
+	if v, ok := q.Peek(); v != 1 || !ok {
+		t.Errorf("Got %v expected %v", v, 1)
+	}
+	for _, expected := range []int{1, 3, 5} {
+		if v, ok := q.TryPop(); v != expected || !ok {
+			t.Errorf("Got %v expected %v", v, expected)
+		}
+	}
+	if actualValue := q.Len(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestPopBlocks(t *testing.T) {
+	q := New[int]()
+	done := make(chan int)
+	go func() {
+		v, err := q.Pop(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		done <- v
+	}()
+	select {
+	case <-done:
+		t.Fatal("Pop returned from an empty queue")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if err := q.Push(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	if v := <-done; v != 7 {
+		t.Errorf("Got %v expected %v", v, 7)
+	}
+}
+
+func TestPushBlocks(t *testing.T) {
+	q := New[int](WithLimit[int](1))
+	ctx := context.Background()
+	if err := q.Push(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() { done <- q.Push(ctx, 2) }()
+	select {
+	case <-done:
+		t.Fatal("Push returned on a full queue")
+	case <-time.After(10 * time.Millisecond):
+	}
+	if v, err := q.Pop(ctx); v != 1 || err != nil {
+		t.Errorf("Got %v, %v expected %v", v, err, 1)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := q.TryPop(); v != 2 || !ok {
+		t.Errorf("Got %v expected %v", v, 2)
+	}
+}
+
+func TestContext(t *testing.T) {
+	q := New[int](WithLimit[int](1))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Got %v expected %v", err, context.DeadlineExceeded)
+	}
+	q.TryPush(1)
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := q.Push(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Got %v expected %v", err, context.Canceled)
+	}
+	if actualValue := q.Len(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestClose(t *testing.T) {
+	q := New[int](WithLimit[int](2))
+	ctx := context.Background()
+	q.TryPush(2)
+	q.TryPush(1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := q.Push(ctx, 3); !errors.Is(err, ErrClosed) {
+			t.Errorf("Got %v expected %v", err, ErrClosed)
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	q.Close()
+	wg.Wait()
+
+	if !q.Closed() || q.TryPush(0) {
+		t.Error("expected a closed queue to reject pushes")
+	}
+	for _, expected := range []int{1, 2} {
+		if v, err := q.Pop(ctx); v != expected || err != nil {
+			t.Errorf("Got %v, %v expected %v", v, err, expected)
+		}
+	}
+	if _, err := q.Pop(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Got %v expected %v", err, ErrClosed)
+	}
+}
+
+func TestCloseWakesPop(t *testing.T) {
+	q := New[int]()
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := q.Pop(context.Background()); !errors.Is(err, ErrClosed) {
+				t.Errorf("Got %v expected %v", err, ErrClosed)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+}
+
+func TestWakeOne(t *testing.T) {
+	q := New[int]()
+	done := make(chan int, 4)
+	for range 4 {
+		go func() {
+			v, _ := q.Pop(context.Background())
+			done <- v
+		}()
+	}
+	waiting := func(n int) {
+		for q.waiting() != n {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	waiting(4)
+	q.TryPush(1)
+	if v := <-done; v != 1 {
+		t.Errorf("Got %v expected %v", v, 1)
+	}
+	waiting(3)
+	select {
+	case v := <-done:
+		t.Fatalf("a second Pop returned %v", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+	q.Close()
+	for range 3 {
+		<-done
+	}
+}
+
+func TestPushAllocs(t *testing.T) {
+	q := New[int](WithLimit[int](8))
+	allocs := testing.AllocsPerRun(100, func() {
+		q.TryPush(1)
+		q.TryPop()
+	})
+	if allocs != 0 {
+		t.Errorf("Got %v allocations expected none", allocs)
+	}
+}
+
+func TestCancelPassesWakeUp(t *testing.T) {
+	q := New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := q.Pop(ctx)
+		first <- err
+	}()
+	for q.waiting() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	second := make(chan int)
+	go func() {
+		v, _ := q.Pop(context.Background())
+		second <- v
+	}()
+	for q.waiting() != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	// wake the first waiter and cancel it before it runs, the item must reach the second
+	q.mu.Lock()
+	q.push(7)
+	cancel()
+	q.mu.Unlock()
+	if err := <-first; err == nil {
+		// the first waiter won the race with its context and took the item
+		q.Close()
+		<-second
+		return
+	} else if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Got %v expected %v", err, context.Canceled)
+	}
+	if v := <-second; v != 7 {
+		t.Errorf("Got %v expected %v", v, 7)
+	}
+}
+
+func TestWorkers(t *testing.T) {
+	q := NewWith(func(a, b int) int { return b - a }, WithLimit[int](8))
+	ctx := context.Background()
+	const n = 1000
+	var (
+		mu  sync.Mutex
+		sum int
+		wg  sync.WaitGroup
+	)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, err := q.Pop(ctx)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				sum += v
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := 1; i <= n; i++ {
+		if err := q.Push(ctx, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	q.Close()
+	wg.Wait()
+	if expected := n * (n + 1) / 2; sum != expected {
+		t.Errorf("Got %v expected %v", sum, expected)
+	}
+}
+
+func (q *Queue[T]) waiting() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pops.Len()
+}
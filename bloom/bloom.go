@@ -0,0 +1,145 @@
+/*
+Package bloom implements bloom filters.
+
+A bloom filter is a space-efficient probabilistic data structure
+that is used to test whether an element is a member of a set.
+False positive matches are possible, but false negatives are not:
+a query returns either "possibly in set" or "definitely not in set".
+
+An empty filter is a bit array of m bits, all set to false.
+To add an element, feed it to k hash functions to get k array positions and set the bits at all these positions.
+To query for an element, test the bits at the k positions,
+if any of them is false, the element is definitely not in the set.
+*/
+package bloom
+
+import (
+	"errors"
+	"math"
+
+	"github.com/zrcoder/dsgo/bitset"
+	"github.com/zrcoder/dsgo/internal/hashing"
+)
+
+var ErrIncompatible = errors.New("bloom: filters have different parameters")
+
+// maxK bounds the number of hash functions, far above what any useful false positive rate needs
+const maxK = 256
+
+// Filter is a bloom filter with m bits and k hash functions
+type Filter struct {
+	m, k int
+	bits bitset.BitSet
+}
+
+// New creates a filter sized to hold n items with a false positive rate of fp
+func New(n int, fp float64) *Filter {
+	return NewWith(EstimateParameters(n, fp))
+}
+
+// NewWith creates a filter with m bits and k hash functions
+func NewWith(m, k int) *Filter {
+	if m < 1 || k < 1 || k > maxK {
+		panic("bloom: m must be at least 1 and k in [1, 256]")
+	}
+	return &Filter{m: m, k: k, bits: bitset.New(m)}
+}
+
+// EstimateParameters returns the optimal bit count m and hash count k
+// for a filter holding n items with a false positive rate of fp
+func EstimateParameters(n int, fp float64) (m, k int) {
+	if n < 1 {
+		n = 1
+	}
+	if fp <= 0 || fp >= 1 {
+		panic("bloom: false positive rate must be in (0, 1)")
+	}
+	m = int(math.Ceil(-float64(n) * math.Log(fp) / (math.Ln2 * math.Ln2)))
+	k = int(math.Round(float64(m) / float64(n) * math.Ln2))
+	return max(m, 1), max(k, 1)
+}
+
+// Cap returns the number of bits m
+func (f *Filter) Cap() int { return f.m }
+
+// K returns the number of hash functions k
+func (f *Filter) K() int { return f.k }
+
+// Add adds data to the filter
+func (f *Filter) Add(data []byte) {
+	h1, h2 := hashing.Sum128(data)
+	for i := 0; i < f.k; i++ {
+		f.bits.Set(location(h1, h2, i, f.m))
+	}
+}
+
+// Test returns true if data is possibly in the filter, false if it is definitely not
+func (f *Filter) Test(data []byte) bool {
+	h1, h2 := hashing.Sum128(data)
+	for i := 0; i < f.k; i++ {
+		if !f.bits.Get(location(h1, h2, i, f.m)) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAndAdd adds data to the filter, and returns the result of Test before adding
+func (f *Filter) TestAndAdd(data []byte) bool {
+	h1, h2 := hashing.Sum128(data)
+	present := true
+	for i := 0; i < f.k; i++ {
+		loc := location(h1, h2, i, f.m)
+		if !f.bits.Get(loc) {
+			present = false
+			f.bits.Set(loc)
+		}
+	}
+	return present
+}
+
+// Union merges other into f, both filters must have the same m and k
+func (f *Filter) Union(other *Filter) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatible
+	}
+	for i, b := range other.bits {
+		f.bits[i] |= b
+	}
+	return nil
+}
+
+// EstimatedCount returns the approximate number of distinct items added,
+// computed from the fraction of bits set as -m/k * ln(1 - x/m)
+func (f *Filter) EstimatedCount() int {
+	x := float64(f.bits.Count())
+	m := float64(f.m)
+	if x >= m {
+		return math.MaxInt
+	}
+	return int(math.Round(-m / float64(f.k) * math.Log(1-x/m)))
+}
+
+// FalsePositiveRate returns the expected false positive rate with the current fill
+func (f *Filter) FalsePositiveRate() float64 {
+	return math.Pow(float64(f.bits.Count())/float64(f.m), float64(f.k))
+}
+
+// Clear removes all items from the filter
+func (f *Filter) Clear() {
+	clear(f.bits)
+}
+
+// Clone returns a copy of the filter
+func (f *Filter) Clone() *Filter {
+	bits := make(bitset.BitSet, len(f.bits))
+	copy(bits, f.bits)
+	return &Filter{m: f.m, k: f.k, bits: bits}
+}
+
+// location returns the i-th probe of m slots using enhanced double hashing.
+// The quadratic term keeps the probes apart even when h2 is a multiple of m.
+func location(h1, h2 uint64, i, m int) int {
+	mm, ii := uint64(m), uint64(i)
+	return int((h1%mm + ii*(h2%mm)%mm + ii*ii%mm) % mm)
+}
@@ -0,0 +1,296 @@
+package bloom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/zrcoder/dsgo/bitset"
+)
+
+func key(i int) []byte {
+	return []byte("event-" + strconv.Itoa(i))
+}
+
+func TestEstimateParameters(t *testing.T) {
+	m, k := EstimateParameters(1000, 0.01)
+	if m != 9586 || k != 7 {
+		t.Errorf("Got m=%d k=%d expected m=%d k=%d", m, k, 9586, 7)
+	}
+}
+
+func TestAddTest(t *testing.T) {
+	const n = 10000
+	f := New(n, 0.01)
+	for i := 0; i < n; i++ {
+		f.Add(key(i))
+	}
+	for i := 0; i < n; i++ {
+		if !f.Test(key(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+	fp := 0
+	for i := n; i < 2*n; i++ {
+		if f.Test(key(i)) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / n; rate > 0.02 {
+		t.Errorf("false positive rate %v is too high", rate)
+	}
+	if rate := f.FalsePositiveRate(); rate > 0.02 {
+		t.Errorf("expected false positive rate %v is too high", rate)
+	}
+	if c := f.EstimatedCount(); c < n*95/100 || c > n*105/100 {
+		t.Errorf("Got estimated count %d expected about %d", c, n)
+	}
+	f.Clear()
+	if f.Test(key(0)) || f.EstimatedCount() != 0 {
+		t.Error("expected empty filter after Clear")
+	}
+}
+
+func TestTestAndAdd(t *testing.T) {
+	f := New(100, 0.001)
+	if f.TestAndAdd(key(1)) {
+		t.Errorf("Got %v expected %v", true, false)
+	}
+	if !f.TestAndAdd(key(1)) {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+	if !f.Test(key(1)) {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+}
+
+func TestUnion(t *testing.T) {
+	a, b := New(100, 0.01), New(100, 0.01)
+	a.Add(key(1))
+	b.Add(key(2))
+	if err := a.Union(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Test(key(1)) || !a.Test(key(2)) {
+		t.Error("expected both keys after Union")
+	}
+	if b.Test(key(1)) {
+		t.Error("Union should not modify the other filter")
+	}
+	if err := a.Union(New(1000, 0.01)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Got %v expected %v", err, ErrIncompatible)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	f := New(1000, 0.01)
+	for i := 0; i < 500; i++ {
+		f.Add(key(i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Filter{}
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if g.Cap() != f.Cap() || g.K() != f.K() || !bytes.Equal(g.bits, f.bits) {
+		t.Error("decoded filter differs")
+	}
+	for i := 0; i < 500; i++ {
+		if !g.Test(key(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+
+	if err := g.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Got %v expected %v", err, ErrInvalidData)
+	}
+	if err := g.UnmarshalBinary(append(data, 0)); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Got %v expected %v", err, ErrInvalidData)
+	}
+	data[0] = 0
+	if err := g.UnmarshalBinary(data); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Got %v expected %v", err, ErrUnsupportedVersion)
+	}
+}
+
+func TestUnmarshalCorrupt(t *testing.T) {
+	f := NewWith(64, 3)
+	f.Add(key(1))
+	data, _ := f.MarshalBinary()
+	corrupt := func(i int, b byte) []byte {
+		c := bytes.Clone(data)
+		c[i] = b
+		return c
+	}
+	g := &Filter{}
+	// the embedded bitset's version and bit length
+	if err := g.UnmarshalBinary(corrupt(headerSize, 0)); err != ErrUnsupportedVersion {
+		t.Errorf("Got %v expected %v", err, ErrUnsupportedVersion)
+	}
+	if err := g.UnmarshalBinary(corrupt(len(data)-9, 1)); err != ErrInvalidData {
+		t.Errorf("Got %v expected %v", err, ErrInvalidData)
+	}
+	// k far beyond any useful filter
+	if err := g.UnmarshalBinary(corrupt(9, 1)); err != ErrInvalidData {
+		t.Errorf("Got %v expected %v", err, ErrInvalidData)
+	}
+	// m so large that rounding it up to bytes wraps around to an empty bitset,
+	// m beyond MaxInt, and m a byte short of or a bit beyond the 64 bits
+	empty, _ := bitset.New(0).MarshalBinary()
+	cases := []struct {
+		m    uint64
+		bits []byte
+	}{
+		{math.MaxUint64 - 6, empty},
+		{math.MaxInt + 1, empty},
+		{56, data[headerSize:]},
+		{65, data[headerSize:]},
+	}
+	for _, c := range cases {
+		header := make([]byte, headerSize)
+		header[0] = version
+		binary.BigEndian.PutUint64(header[1:9], c.m)
+		binary.BigEndian.PutUint64(header[9:], 1)
+		if err := g.UnmarshalBinary(append(header, c.bits...)); err != ErrInvalidData {
+			t.Errorf("m %v: Got %v expected %v", c.m, err, ErrInvalidData)
+		}
+	}
+}
+
+func TestLocation(t *testing.T) {
+	const m = 64
+	// h2 a multiple of m used to collapse every probe onto h1
+	seen := map[int]bool{}
+	for i := 0; i < 8; i++ {
+		seen[location(5, 3*m, i, m)] = true
+	}
+	if len(seen) != 8 {
+		t.Errorf("Got %v distinct probes expected 8", len(seen))
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	f := New(b.N, 0.01)
+	data := key(42)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Add(data)
+	}
+}
+
+func BenchmarkTest(b *testing.B) {
+	f := New(b.N, 0.01)
+	data := key(42)
+	f.Add(data)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Test(data)
+	}
+}
+
+func TestCountingFilter(t *testing.T) {
+	const n = 1000
+	f := NewCounting(n, 0.01)
+	for i := 0; i < n; i++ {
+		f.Add(key(i))
+	}
+	for i := 0; i < n; i += 2 {
+		if !f.Remove(key(i)) {
+			t.Fatalf("failed to remove %d", i)
+		}
+	}
+	for i := 1; i < n; i += 2 {
+		if !f.Test(key(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+	fp := 0
+	for i := 0; i < n; i += 2 {
+		if f.Test(key(i)) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / (n / 2); rate > 0.02 {
+		t.Errorf("false positive rate %v after removal is too high", rate)
+	}
+	f.Clear()
+	if f.Test(key(1)) {
+		t.Error("expected empty filter after Clear")
+	}
+}
+
+func TestCountingFilterSaturation(t *testing.T) {
+	f := NewCountingWith(1, 1)
+	for i := 0; i < 20; i++ {
+		f.Add(key(0))
+	}
+	if f.get(0) != maxCounter {
+		t.Errorf("Got %d expected %d", f.get(0), maxCounter)
+	}
+	for i := 0; i < 20; i++ {
+		f.Remove(key(0))
+	}
+	if !f.Test(key(0)) {
+		t.Error("saturated counter must not be decremented")
+	}
+}
+
+func TestScalableFilter(t *testing.T) {
+	const n, fp = 20000, 0.01
+	f := NewScalable(100, fp)
+	for i := 0; i < n; i++ {
+		f.Add(key(i))
+	}
+	if f.Filters() < 2 {
+		t.Errorf("expected the filter to grow, got %d filters", f.Filters())
+	}
+	for i := 0; i < n; i++ {
+		if !f.Test(key(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+	falsePositives := 0
+	for i := n; i < 2*n; i++ {
+		if f.Test(key(i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / n; rate > fp {
+		t.Errorf("false positive rate %v exceeds %v", rate, fp)
+	}
+	if rate := f.FalsePositiveRate(); rate > fp {
+		t.Errorf("expected false positive rate %v exceeds %v", rate, fp)
+	}
+	if c := f.EstimatedCount(); c < n*95/100 || c > n*105/100 {
+		t.Errorf("Got estimated count %d expected about %d", c, n)
+	}
+}
+
+func TestScalableFilterOptions(t *testing.T) {
+	f := NewScalable(10, 0.01, WithGrowth(4), WithTightening(0.5))
+	for i := 0; i < 50; i++ {
+		f.Add(key(i))
+	}
+	if f.Filters() != 2 || f.capacity != 40 {
+		t.Errorf("Got %d filters with capacity %d expected 2 filters with capacity 40", f.Filters(), f.capacity)
+	}
+}
+
+func TestScalableFilterRate(t *testing.T) {
+	for _, fp := range []float64{0, 1, 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for a false positive rate of %v", fp)
+				}
+			}()
+			NewScalable(10, fp)
+		}()
+	}
+}
@@ -0,0 +1,88 @@
+package bloom
+
+import (
+	"github.com/zrcoder/dsgo/internal/hashing"
+)
+
+const maxCounter = 1<<4 - 1
+
+// CountingFilter is a bloom filter that supports removal.
+// Each bit is replaced by a 4-bit saturating counter, two counters packed in a byte.
+// A counter reaching its maximum sticks there and is never decremented,
+// so removing an item never introduces false negatives for other items.
+type CountingFilter struct {
+	m, k     int
+	counters []byte
+}
+
+// NewCounting creates a counting filter sized to hold n items with a false positive rate of fp
+func NewCounting(n int, fp float64) *CountingFilter {
+	return NewCountingWith(EstimateParameters(n, fp))
+}
+
+// NewCountingWith creates a counting filter with m counters and k hash functions
+func NewCountingWith(m, k int) *CountingFilter {
+	if m < 1 || k < 1 || k > maxK {
+		panic("bloom: m must be at least 1 and k in [1, 256]")
+	}
+	return &CountingFilter{m: m, k: k, counters: make([]byte, (m+1)/2)}
+}
+
+// Cap returns the number of counters m
+func (f *CountingFilter) Cap() int { return f.m }
+
+// K returns the number of hash functions k
+func (f *CountingFilter) K() int { return f.k }
+
+// Add adds data to the filter
+func (f *CountingFilter) Add(data []byte) {
+	h1, h2 := hashing.Sum128(data)
+	for i := 0; i < f.k; i++ {
+		loc := location(h1, h2, i, f.m)
+		if c := f.get(loc); c < maxCounter {
+			f.set(loc, c+1)
+		}
+	}
+}
+
+// Test returns true if data is possibly in the filter, false if it is definitely not
+func (f *CountingFilter) Test(data []byte) bool {
+	h1, h2 := hashing.Sum128(data)
+	for i := 0; i < f.k; i++ {
+		if f.get(location(h1, h2, i, f.m)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove removes data from the filter.
+// It returns false and changes nothing if data is definitely not in the filter.
+// Removing data that was never added may cause false negatives for other items.
+func (f *CountingFilter) Remove(data []byte) bool {
+	if !f.Test(data) {
+		return false
+	}
+	h1, h2 := hashing.Sum128(data)
+	for i := 0; i < f.k; i++ {
+		loc := location(h1, h2, i, f.m)
+		if c := f.get(loc); c < maxCounter {
+			f.set(loc, c-1)
+		}
+	}
+	return true
+}
+
+// Clear removes all items from the filter
+func (f *CountingFilter) Clear() {
+	clear(f.counters)
+}
+
+func (f *CountingFilter) get(loc int) byte {
+	return f.counters[loc/2] >> (loc % 2 * 4) & maxCounter
+}
+
+func (f *CountingFilter) set(loc int, c byte) {
+	shift := loc % 2 * 4
+	f.counters[loc/2] = f.counters[loc/2]&^(maxCounter<<shift) | c<<shift
+}
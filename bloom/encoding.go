@@ -0,0 +1,94 @@
+package bloom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/zrcoder/dsgo/bitset"
+)
+
+// The binary form is a fixed header followed by the bitset in its own binary form:
+//
+//	version (1 byte) | m (8 bytes, big endian) | k (8 bytes, big endian) | bitset
+const (
+	version    byte = 1
+	headerSize      = 1 + 8 + 8
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("bloom: unsupported encoding version")
+	ErrInvalidData        = errors.New("bloom: invalid encoded data")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	_, err := f.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	if _, err := f.ReadFrom(r); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrInvalidData
+		}
+		return err
+	}
+	if r.Len() != 0 {
+		return ErrInvalidData
+	}
+	return nil
+}
+
+// WriteTo implements io.WriterTo
+func (f *Filter) WriteTo(w io.Writer) (int64, error) {
+	var header [headerSize]byte
+	header[0] = version
+	binary.BigEndian.PutUint64(header[1:9], uint64(f.m))
+	binary.BigEndian.PutUint64(header[9:], uint64(f.k))
+	n, err := w.Write(header[:])
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := f.bits.WriteTo(w)
+	return int64(n) + m, err
+}
+
+// ReadFrom implements io.ReaderFrom
+func (f *Filter) ReadFrom(r io.Reader) (int64, error) {
+	var header [headerSize]byte
+	n, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return int64(n), err
+	}
+	if header[0] != version {
+		return int64(n), ErrUnsupportedVersion
+	}
+	m := binary.BigEndian.Uint64(header[1:9])
+	k := binary.BigEndian.Uint64(header[9:])
+	var bits bitset.BitSet
+	read, err := bits.ReadFrom(r)
+	if err != nil {
+		switch {
+		case err == io.EOF:
+			err = io.ErrUnexpectedEOF
+		case errors.Is(err, bitset.ErrUnsupportedVersion):
+			err = ErrUnsupportedVersion
+		case errors.Is(err, bitset.ErrInvalidData):
+			err = ErrInvalidData
+		}
+		return int64(n) + read, err
+	}
+	// m must fill the last byte of the bits, compared without adding so that a huge m cannot wrap around
+	size := uint64(len(bits))
+	if m < 1 || m > math.MaxInt || k < 1 || k > maxK || m > size*8 || m <= (size-1)*8 {
+		return int64(n) + read, ErrInvalidData
+	}
+	f.m, f.k, f.bits = int(m), int(k), bits
+	return int64(n) + read, nil
+}
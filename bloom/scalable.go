@@ -0,0 +1,115 @@
+package bloom
+
+const (
+	defaultGrowth     = 2
+	defaultTightening = 0.8
+)
+
+// ScalableFilter is a bloom filter that grows as items arrive.
+// It chains plain filters, each one larger than the previous and with a tighter false positive rate,
+// so the compound false positive rate stays below the target however many items are added.
+type ScalableFilter struct {
+	filters    []*Filter
+	capacity   int // capacity of the last filter
+	count      int // items added to the last filter
+	fp         float64
+	growth     int
+	tightening float64
+}
+
+type ScalableOption func(f *ScalableFilter)
+
+// WithGrowth sets the factor by which each new filter's capacity grows, 2 by default
+func WithGrowth(growth int) ScalableOption {
+	return func(f *ScalableFilter) {
+		if growth < 1 {
+			panic("bloom: growth must be at least 1")
+		}
+		f.growth = growth
+	}
+}
+
+// WithTightening sets the ratio by which each new filter's false positive rate shrinks, 0.8 by default
+func WithTightening(ratio float64) ScalableOption {
+	return func(f *ScalableFilter) {
+		if ratio <= 0 || ratio >= 1 {
+			panic("bloom: tightening ratio must be in (0, 1)")
+		}
+		f.tightening = ratio
+	}
+}
+
+// NewScalable creates a scalable filter with an initial capacity of n items
+// and a compound false positive rate bounded by fp
+func NewScalable(n int, fp float64, ops ...ScalableOption) *ScalableFilter {
+	if fp <= 0 || fp >= 1 {
+		panic("bloom: false positive rate must be in (0, 1)")
+	}
+	f := &ScalableFilter{
+		capacity:   max(n, 1),
+		growth:     defaultGrowth,
+		tightening: defaultTightening,
+	}
+	for _, op := range ops {
+		op(f)
+	}
+	// the rates of the chain form a geometric series fp0 * r^i, whose sum is fp0 / (1-r)
+	f.fp = fp * (1 - f.tightening)
+	f.filters = []*Filter{New(f.capacity, f.fp)}
+	return f
+}
+
+// Add adds data to the filter, it does nothing if data may already be present
+func (f *ScalableFilter) Add(data []byte) {
+	if f.Test(data) {
+		return
+	}
+	if f.count >= f.capacity {
+		f.capacity *= f.growth
+		f.fp *= f.tightening
+		f.filters = append(f.filters, New(f.capacity, f.fp))
+		f.count = 0
+	}
+	f.filters[len(f.filters)-1].Add(data)
+	f.count++
+}
+
+// Test returns true if data is possibly in the filter, false if it is definitely not
+func (f *ScalableFilter) Test(data []byte) bool {
+	for i := len(f.filters) - 1; i >= 0; i-- {
+		if f.filters[i].Test(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestAndAdd adds data to the filter, and returns the result of Test before adding
+func (f *ScalableFilter) TestAndAdd(data []byte) bool {
+	if f.Test(data) {
+		return true
+	}
+	f.Add(data)
+	return false
+}
+
+// Filters returns the number of chained filters
+func (f *ScalableFilter) Filters() int { return len(f.filters) }
+
+// EstimatedCount returns the approximate number of distinct items added
+func (f *ScalableFilter) EstimatedCount() int {
+	res := 0
+	for _, filter := range f.filters {
+		res += filter.EstimatedCount()
+	}
+	return res
+}
+
+// FalsePositiveRate returns the expected compound false positive rate with the current fill
+func (f *ScalableFilter) FalsePositiveRate() float64 {
+	miss := 1.0
+	for _, filter := range f.filters {
+		miss *= 1 - filter.FalsePositiveRate()
+	}
+	return 1 - miss
+}
@@ -0,0 +1,127 @@
+/*
+Package countmin implements the count-min sketch, and a heavy hitters tracker built on it.
+
+A count-min sketch estimates the frequencies of items in a stream in sub-linear space.
+It is a depth x width matrix of counters, each row with its own hash function.
+Adding an item increments one counter per row, the estimate is the minimum of those counters.
+Estimates never undercount, and with probability 1-delta they overcount by at most epsilon times the total,
+where width = e/epsilon and depth = ln(1/delta).
+*/
+package countmin
+
+import (
+	"errors"
+	"math"
+
+	"github.com/zrcoder/dsgo/internal/hashing"
+)
+
+var ErrIncompatible = errors.New("countmin: sketches have different dimensions")
+
+// Sketch is a count-min sketch
+type Sketch struct {
+	width, depth int
+	counts       []uint64 // depth rows of width counters
+	total        uint64
+	conservative bool
+}
+
+type Option func(s *Sketch)
+
+// WithConservativeUpdate makes Add only raise the counters that are below the new estimate,
+// which leaves the estimates still never undercounting but much tighter.
+// The sketch can no longer handle negative updates, which it does not support anyway.
+func WithConservativeUpdate() Option {
+	return func(s *Sketch) {
+		s.conservative = true
+	}
+}
+
+// New creates a sketch with depth rows of width counters
+func New(width, depth int, ops ...Option) *Sketch {
+	if width < 1 || depth < 1 {
+		panic("countmin: width and depth must be at least 1")
+	}
+	s := &Sketch{width: width, depth: depth, counts: make([]uint64, width*depth)}
+	for _, op := range ops {
+		op(s)
+	}
+	return s
+}
+
+// NewWithEstimates creates a sketch whose estimates exceed the true counts by at most epsilon*total
+// with probability 1-delta
+func NewWithEstimates(epsilon, delta float64, ops ...Option) *Sketch {
+	if epsilon <= 0 || delta <= 0 || delta >= 1 {
+		panic("countmin: epsilon must be positive and delta in (0, 1)")
+	}
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return New(width, depth, ops...)
+}
+
+// Width returns the number of counters in a row
+func (s *Sketch) Width() int { return s.width }
+
+// Depth returns the number of rows
+func (s *Sketch) Depth() int { return s.depth }
+
+// Total returns the sum of all counts added
+func (s *Sketch) Total() uint64 { return s.total }
+
+// Add adds count occurrences of data, and returns the new estimate of data
+func (s *Sketch) Add(data []byte, count uint64) uint64 {
+	h1, h2 := hashing.Sum128(data)
+	s.total += count
+	if !s.conservative {
+		est := uint64(math.MaxUint64)
+		for i := 0; i < s.depth; i++ {
+			j := s.index(h1, h2, i)
+			s.counts[j] += count
+			est = min(est, s.counts[j])
+		}
+		return est
+	}
+	est := s.estimate(h1, h2) + count
+	for i := 0; i < s.depth; i++ {
+		j := s.index(h1, h2, i)
+		s.counts[j] = max(s.counts[j], est)
+	}
+	return est
+}
+
+// Estimate returns the estimated count of data, which is never less than the true count
+func (s *Sketch) Estimate(data []byte) uint64 {
+	h1, h2 := hashing.Sum128(data)
+	return s.estimate(h1, h2)
+}
+
+// Merge adds the counts of other into s, both sketches must have the same dimensions
+func (s *Sketch) Merge(other *Sketch) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrIncompatible
+	}
+	for i, c := range other.counts {
+		s.counts[i] += c
+	}
+	s.total += other.total
+	return nil
+}
+
+// Clear resets all counters
+func (s *Sketch) Clear() {
+	clear(s.counts)
+	s.total = 0
+}
+
+func (s *Sketch) estimate(h1, h2 uint64) uint64 {
+	est := uint64(math.MaxUint64)
+	for i := 0; i < s.depth; i++ {
+		est = min(est, s.counts[s.index(h1, h2, i)])
+	}
+	return est
+}
+
+func (s *Sketch) index(h1, h2 uint64, row int) int {
+	return row*s.width + int((h1+uint64(row)*h2)%uint64(s.width))
+}
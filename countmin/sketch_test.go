@@ -0,0 +1,143 @@
+package countmin
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func key(i int) []byte {
+	return []byte("key-" + strconv.Itoa(i))
+}
+
+func TestNewWithEstimates(t *testing.T) {
+	s := NewWithEstimates(0.001, 0.01)
+	if s.Width() != 2719 || s.Depth() != 5 {
+		t.Errorf("Got width=%d depth=%d expected width=%d depth=%d", s.Width(), s.Depth(), 2719, 5)
+	}
+}
+
+func testEstimates(t *testing.T, s *Sketch) uint64 {
+	t.Helper()
+	const n = 1000
+	counts := make(map[int]uint64)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50*n; i++ {
+		k := int(r.ExpFloat64() * n / 10)
+		counts[k]++
+		s.Add(key(k), 1)
+	}
+	if s.Total() != 50*n {
+		t.Errorf("Got total %d expected %d", s.Total(), 50*n)
+	}
+	var overcount uint64
+	for k, c := range counts {
+		est := s.Estimate(key(k))
+		if est < c {
+			t.Fatalf("estimate %d of %d undercounts %d", est, k, c)
+		}
+		overcount += est - c
+	}
+	return overcount
+}
+
+func TestEstimate(t *testing.T) {
+	plain := testEstimates(t, New(200, 4))
+	conservative := testEstimates(t, New(200, 4, WithConservativeUpdate()))
+	if conservative >= plain {
+		t.Errorf("conservative update overcounts %d, plain %d", conservative, plain)
+	}
+}
+
+func TestAddCount(t *testing.T) {
+	s := New(100, 3, WithConservativeUpdate())
+	if est := s.Add(key(1), 5); est != 5 {
+		t.Errorf("Got %v expected %v", est, 5)
+	}
+	if est := s.Add(key(1), 2); est != 7 {
+		t.Errorf("Got %v expected %v", est, 7)
+	}
+	if est := s.Estimate(key(1)); est != 7 {
+		t.Errorf("Got %v expected %v", est, 7)
+	}
+	s.Clear()
+	if est := s.Estimate(key(1)); est != 0 || s.Total() != 0 {
+		t.Errorf("Got %v expected %v", est, 0)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a, b := New(100, 3), New(100, 3)
+	a.Add(key(1), 3)
+	b.Add(key(1), 4)
+	b.Add(key(2), 1)
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if est := a.Estimate(key(1)); est != 7 {
+		t.Errorf("Got %v expected %v", est, 7)
+	}
+	if a.Total() != 8 {
+		t.Errorf("Got %v expected %v", a.Total(), 8)
+	}
+	if err := a.Merge(New(10, 3)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Got %v expected %v", err, ErrIncompatible)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	tk := NewTopK(3, NewWithEstimates(0.001, 0.001))
+	for i := 0; i < 100; i++ {
+		tk.Add("noise-"+strconv.Itoa(i), 1)
+	}
+	tk.Add("a", 50)
+	tk.Add("b", 30)
+	for i := 0; i < 40; i++ {
+		tk.Add("c", 1)
+	}
+	tk.Add("d", 2)
+
+	top := tk.Top()
+	if len(top) != 3 || tk.Len() != 3 {
+		t.Fatalf("Got %v expected 3 keys", top)
+	}
+	for i, expected := range []string{"a", "c", "b"} {
+		if top[i].Key != expected {
+			t.Errorf("Got %v expected %v at %d", top[i].Key, expected, i)
+		}
+	}
+	if top[0].Value != 50 || top[1].Value != 40 {
+		t.Errorf("Got %v", top)
+	}
+	if tk.Contains("d") || !tk.Contains("b") {
+		t.Error("unexpected membership")
+	}
+	if !tk.Add("d", 100) || !tk.Contains("d") || tk.Contains("b") {
+		t.Error("expected d to replace b")
+	}
+	tk.Clear()
+	if tk.Len() != 0 || len(tk.Top()) != 0 {
+		t.Error("expected empty tracker after Clear")
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	s := NewWithEstimates(0.001, 0.01)
+	data := key(42)
+	for i := 0; i < b.N; i++ {
+		s.Add(data, 1)
+	}
+}
+
+func BenchmarkTopKAdd(b *testing.B) {
+	tk := NewTopK(100, NewWithEstimates(0.001, 0.01, WithConservativeUpdate()))
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = string(key(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tk.Add(keys[i%len(keys)], 1)
+	}
+}
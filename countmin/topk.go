@@ -0,0 +1,94 @@
+package countmin
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/heapx"
+)
+
+// TopK tracks the k most frequent keys of a stream, the heavy hitters, in bounded memory.
+// Frequencies come from a count-min sketch, and the current top keys are kept in a min heap by estimate,
+// so a new key only enters when its estimate beats the least frequent one tracked.
+type TopK struct {
+	k       int
+	sketch  *Sketch
+	heap    *heapx.Heap[*entry]
+	entries map[string]*entry
+}
+
+type entry struct {
+	key   string
+	count uint64
+}
+
+func compareEntries(a, b *entry) int {
+	if c := cmp.Compare(a.count, b.count); c != 0 {
+		return c
+	}
+	return cmp.Compare(b.key, a.key)
+}
+
+// NewTopK creates a tracker of the k most frequent keys counted with sketch
+func NewTopK(k int, sketch *Sketch) *TopK {
+	if k < 1 {
+		panic("countmin: k must be at least 1")
+	}
+	return &TopK{
+		k:       k,
+		sketch:  sketch,
+		heap:    heapx.NewWith(compareEntries, heapx.WithCapacity[*entry](k)),
+		entries: make(map[string]*entry, k),
+	}
+}
+
+// Add adds count occurrences of key, and returns true if key is among the top k afterwards
+func (t *TopK) Add(key string, count uint64) bool {
+	est := t.sketch.Add([]byte(key), count)
+	if e, ok := t.entries[key]; ok {
+		e.count = est
+		t.heap.Update(e)
+		return true
+	}
+	e := &entry{key: key, count: est}
+	if t.heap.Len() == t.k {
+		least, _ := t.heap.Peek()
+		if compareEntries(e, least) <= 0 {
+			return false
+		}
+		t.heap.Pop()
+		delete(t.entries, least.key)
+	}
+	t.heap.Push(e)
+	t.entries[key] = e
+	return true
+}
+
+// Contains returns true if key is among the top k
+func (t *TopK) Contains(key string) bool {
+	_, ok := t.entries[key]
+	return ok
+}
+
+// Top returns the top keys with their estimated counts, most frequent first
+func (t *TopK) Top() []dsgo.Pair[string, uint64] {
+	res := make([]dsgo.Pair[string, uint64], 0, len(t.entries))
+	for _, e := range t.entries {
+		res = append(res, dsgo.Pair[string, uint64]{Key: e.key, Value: e.count})
+	}
+	slices.SortFunc(res, func(a, b dsgo.Pair[string, uint64]) int {
+		return -compareEntries(&entry{a.Key, a.Value}, &entry{b.Key, b.Value})
+	})
+	return res
+}
+
+// Len returns the number of keys tracked, at most k
+func (t *TopK) Len() int { return len(t.entries) }
+
+// Clear resets the tracker and its sketch
+func (t *TopK) Clear() {
+	t.sketch.Clear()
+	t.heap.Clear()
+	clear(t.entries)
+}
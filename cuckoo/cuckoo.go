@@ -0,0 +1,245 @@
+/*
+Package cuckoo implements a cuckoo filter.
+
+A cuckoo filter is a probabilistic set like a bloom filter, it stores short fingerprints of items
+in a cuckoo hash table where each item has two candidate buckets.
+Unlike a bloom filter it supports deletion, and at low false positive rates it uses less space
+than a bloom filter, let alone a counting bloom filter.
+
+The false positive rate is about 2*b / 2^f for bucket size b and fingerprint size f in bits.
+*/
+package cuckoo
+
+import (
+	"math/bits"
+
+	"github.com/zrcoder/dsgo/internal/hashing"
+)
+
+const (
+	defaultFingerprintBits = 16
+	defaultBucketSize      = 4
+	maxKicks               = 500
+	maxLoad                = 0.96
+)
+
+// Filter is a cuckoo filter, fingerprints 0 marks an empty slot
+type Filter struct {
+	table      []byte // numBuckets * bucketSize fingerprints of fpBits each, packed little endian
+	numBuckets uint64 // a power of 2
+	bucketSize int
+	fpBits     int
+	count      int
+	victim     victim // the fingerprint left homeless by a failed insertion
+	seed       uint64 // state of the random generator choosing which fingerprint to kick out
+}
+
+type victim struct {
+	used bool
+	fp   uint16
+	i    uint64
+}
+
+type Option func(f *Filter)
+
+// WithFingerprintBits sets the size of fingerprints in bits, from 1 to 16, 16 by default.
+// Each slot takes exactly that many bits, so shorter fingerprints save space at the cost of false positives.
+func WithFingerprintBits(n int) Option {
+	return func(f *Filter) {
+		if n < 1 || n > 16 {
+			panic("cuckoo: fingerprint bits must be in [1, 16]")
+		}
+		f.fpBits = n
+	}
+}
+
+// WithBucketSize sets the number of fingerprints per bucket, 4 by default
+func WithBucketSize(n int) Option {
+	return func(f *Filter) {
+		if n < 1 {
+			panic("cuckoo: bucket size must be at least 1")
+		}
+		f.bucketSize = n
+	}
+}
+
+// New creates a filter able to hold capacity items
+func New(capacity int, ops ...Option) *Filter {
+	f := &Filter{
+		fpBits:     defaultFingerprintBits,
+		bucketSize: defaultBucketSize,
+		seed:       1,
+	}
+	for _, op := range ops {
+		op(f)
+	}
+	buckets := uint64(max(capacity, 1)+f.bucketSize-1) / uint64(f.bucketSize)
+	f.numBuckets = 1 << bits.Len64(buckets-1)
+	if float64(capacity)/float64(f.numBuckets*uint64(f.bucketSize)) > maxLoad {
+		f.numBuckets <<= 1
+	}
+	f.table = make([]byte, tableSize(f.numBuckets*uint64(f.bucketSize), f.fpBits))
+	return f
+}
+
+// Insert adds data to the filter.
+// It returns false if the filter is too full to take it.
+// Inserting the same data twice stores two copies, which need two deletions.
+func (f *Filter) Insert(data []byte) bool {
+	if f.victim.used {
+		return false
+	}
+	fp, i1 := f.fingerprintIndex(data)
+	if f.insertInto(i1, fp) || f.insertInto(f.altIndex(i1, fp), fp) {
+		f.count++
+		return true
+	}
+	i := i1
+	if f.random()%2 == 1 {
+		i = f.altIndex(i1, fp)
+	}
+	for n := 0; n < maxKicks; n++ {
+		slot := f.slotIndex(i, int(f.random()%uint64(f.bucketSize)))
+		old := f.get(slot)
+		f.set(slot, fp)
+		fp = old
+		i = f.altIndex(i, fp)
+		if f.insertInto(i, fp) {
+			f.count++
+			return true
+		}
+	}
+	f.victim = victim{used: true, fp: fp, i: i}
+	f.count++
+	return true
+}
+
+// Lookup returns true if data is possibly in the filter, false if it is definitely not
+func (f *Filter) Lookup(data []byte) bool {
+	fp, i1 := f.fingerprintIndex(data)
+	i2 := f.altIndex(i1, fp)
+	if f.victim.used && f.victim.fp == fp && (f.victim.i == i1 || f.victim.i == i2) {
+		return true
+	}
+	return f.find(i1, fp) >= 0 || f.find(i2, fp) >= 0
+}
+
+// Delete removes one copy of data from the filter, and returns false if it is not found.
+// Deleting data that was never inserted may remove another item sharing its fingerprint.
+func (f *Filter) Delete(data []byte) bool {
+	fp, i1 := f.fingerprintIndex(data)
+	i2 := f.altIndex(i1, fp)
+	if f.victim.used && f.victim.fp == fp && (f.victim.i == i1 || f.victim.i == i2) {
+		f.victim = victim{}
+		f.count--
+		return true
+	}
+	slot := f.find(i1, fp)
+	if slot < 0 {
+		slot = f.find(i2, fp)
+	}
+	if slot < 0 {
+		return false
+	}
+	f.set(uint64(slot), 0)
+	f.count--
+	f.rehomeVictim()
+	return true
+}
+
+// Count returns the number of items in the filter
+func (f *Filter) Count() int { return f.count }
+
+// Cap returns the number of fingerprint slots
+func (f *Filter) Cap() int { return int(f.numBuckets) * f.bucketSize }
+
+// LoadFactor returns the fraction of slots in use
+func (f *Filter) LoadFactor() float64 {
+	return float64(f.count) / float64(f.Cap())
+}
+
+// Clear removes all items from the filter
+func (f *Filter) Clear() {
+	clear(f.table)
+	f.count = 0
+	f.victim = victim{}
+}
+
+func (f *Filter) fingerprintIndex(data []byte) (uint16, uint64) {
+	h := hashing.Sum64(data)
+	fp := uint16(h>>32) & (1<<f.fpBits - 1)
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, h & (f.numBuckets - 1)
+}
+
+// altIndex returns the other candidate bucket, it is an involution: altIndex(altIndex(i, fp), fp) == i
+func (f *Filter) altIndex(i uint64, fp uint16) uint64 {
+	return (i ^ hashing.Mix64(uint64(fp))) & (f.numBuckets - 1)
+}
+
+func (f *Filter) slotIndex(bucket uint64, j int) uint64 {
+	return bucket*uint64(f.bucketSize) + uint64(j)
+}
+
+func (f *Filter) insertInto(bucket uint64, fp uint16) bool {
+	for j := 0; j < f.bucketSize; j++ {
+		if slot := f.slotIndex(bucket, j); f.get(slot) == 0 {
+			f.set(slot, fp)
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filter) find(bucket uint64, fp uint16) int {
+	for j := 0; j < f.bucketSize; j++ {
+		if slot := f.slotIndex(bucket, j); f.get(slot) == fp {
+			return int(slot)
+		}
+	}
+	return -1
+}
+
+// tableSize returns the bytes needed for n fingerprints of fpBits each,
+// with 2 bytes of padding so that get and set can always access 3 bytes
+func tableSize(n uint64, fpBits int) uint64 {
+	return (n*uint64(fpBits)+7)/8 + 2
+}
+
+// get returns the fingerprint in slot, which spans at most 3 bytes
+func (f *Filter) get(slot uint64) uint16 {
+	off := slot * uint64(f.fpBits)
+	i, shift := off/8, off%8
+	b := f.table[i : i+3]
+	v := uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+	return uint16(v>>shift) & (1<<f.fpBits - 1)
+}
+
+// set stores fp in slot, leaving the neighbouring bits untouched
+func (f *Filter) set(slot uint64, fp uint16) {
+	off := slot * uint64(f.fpBits)
+	i, shift := off/8, off%8
+	b := f.table[i : i+3]
+	v := uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+	mask := uint32(1<<f.fpBits-1) << shift
+	v = v&^mask | uint32(fp)<<shift&mask
+	b[0], b[1], b[2] = byte(v), byte(v>>8), byte(v>>16)
+}
+
+// rehomeVictim moves the homeless fingerprint into a freed slot if it fits
+func (f *Filter) rehomeVictim() {
+	v := f.victim
+	if v.used && (f.insertInto(v.i, v.fp) || f.insertInto(f.altIndex(v.i, v.fp), v.fp)) {
+		f.victim = victim{}
+	}
+}
+
+// random is a xorshift generator, deterministic so that filters are reproducible
+func (f *Filter) random() uint64 {
+	f.seed ^= f.seed << 13
+	f.seed ^= f.seed >> 7
+	f.seed ^= f.seed << 17
+	return f.seed
+}
@@ -0,0 +1,192 @@
+package cuckoo
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func key(i int) []byte {
+	return []byte("cert-" + strconv.Itoa(i))
+}
+
+func TestNew(t *testing.T) {
+	f := New(900)
+	if f.Cap() != 1024 {
+		t.Errorf("Got %v expected %v", f.Cap(), 1024)
+	}
+	f = New(900, WithBucketSize(2), WithFingerprintBits(8))
+	if f.Cap() != 1024 || f.bucketSize != 2 || f.fpBits != 8 {
+		t.Errorf("Got cap %v bucket size %v fingerprint bits %v", f.Cap(), f.bucketSize, f.fpBits)
+	}
+	f = New(990)
+	if f.Cap() != 2048 {
+		t.Errorf("Got %v expected %v", f.Cap(), 2048)
+	}
+}
+
+func TestPacking(t *testing.T) {
+	for bits := 1; bits <= 16; bits++ {
+		f := New(1000, WithFingerprintBits(bits))
+		if expected := f.Cap()*bits/8 + 2; len(f.table) != expected {
+			t.Errorf("%d bits: Got %v bytes expected %v", bits, len(f.table), expected)
+		}
+		want := make([]uint16, f.Cap())
+		for i := range want {
+			want[i] = uint16(i*2654435761>>7) & (1<<bits - 1)
+			f.set(uint64(i), want[i])
+		}
+		for i := range want {
+			if actualValue := f.get(uint64(i)); actualValue != want[i] {
+				t.Fatalf("%d bits: slot %d Got %v expected %v", bits, i, actualValue, want[i])
+			}
+		}
+	}
+	f8, f16 := New(1000, WithFingerprintBits(8)), New(1000)
+	data8, _ := f8.MarshalBinary()
+	data16, _ := f16.MarshalBinary()
+	if len(data8)-headerSize != (len(data16)-headerSize)/2 {
+		t.Errorf("Got %v bytes for 8 bits and %v for 16 bits", len(data8), len(data16))
+	}
+}
+
+func TestInsertLookupDelete(t *testing.T) {
+	const n = 10000
+	f := New(n)
+	for i := 0; i < n; i++ {
+		if !f.Insert(key(i)) {
+			t.Fatalf("failed to insert %d", i)
+		}
+	}
+	if f.Count() != n {
+		t.Errorf("Got %v expected %v", f.Count(), n)
+	}
+	if lf := f.LoadFactor(); lf < 0.6 {
+		t.Errorf("load factor %v is too low", lf)
+	}
+	for i := 0; i < n; i++ {
+		if !f.Lookup(key(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+	fp := 0
+	for i := n; i < 2*n; i++ {
+		if f.Lookup(key(i)) {
+			fp++
+		}
+	}
+	if rate := float64(fp) / n; rate > 0.001 {
+		t.Errorf("false positive rate %v is too high", rate)
+	}
+
+	for i := 0; i < n; i += 2 {
+		if !f.Delete(key(i)) {
+			t.Fatalf("failed to delete %d", i)
+		}
+	}
+	if f.Count() != n/2 {
+		t.Errorf("Got %v expected %v", f.Count(), n/2)
+	}
+	for i := 1; i < n; i += 2 {
+		if !f.Lookup(key(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+	f.Clear()
+	if f.Count() != 0 || f.Lookup(key(1)) || f.Delete(key(1)) {
+		t.Error("expected empty filter after Clear")
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	f := New(10)
+	f.Insert(key(1))
+	f.Insert(key(1))
+	f.Delete(key(1))
+	if !f.Lookup(key(1)) {
+		t.Error("expected the second copy to remain")
+	}
+	f.Delete(key(1))
+	if f.Lookup(key(1)) {
+		t.Error("expected no copy to remain")
+	}
+}
+
+func TestFull(t *testing.T) {
+	f := New(64, WithFingerprintBits(8))
+	inserted := 0
+	for i := 0; f.Insert(key(i)); i++ {
+		inserted++
+	}
+	if inserted < f.Cap()*9/10 || f.Count() != inserted || !f.victim.used {
+		t.Errorf("inserted %d into %d slots", inserted, f.Cap())
+	}
+	for i := 0; i < inserted; i++ {
+		if !f.Lookup(key(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+	for i := 0; i < inserted/2; i++ {
+		f.Delete(key(i))
+	}
+	if f.victim.used {
+		t.Error("expected the victim to take a freed slot")
+	}
+	if !f.Insert(key(0)) {
+		t.Error("expected room after deletion")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	f := New(100, WithFingerprintBits(12), WithBucketSize(2))
+	for i := 0; i < 80; i++ {
+		f.Insert(key(i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := &Filter{}
+	if err := g.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if g.Count() != f.Count() || g.fpBits != f.fpBits || g.bucketSize != f.bucketSize ||
+		g.victim != f.victim || !slices.Equal(g.table, f.table) {
+		t.Error("decoded filter differs")
+	}
+	for i := 0; i < 80; i++ {
+		if !g.Lookup(key(i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+	if err := g.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("Got %v expected %v", err, ErrInvalidData)
+	}
+	data[0] = 0
+	if err := g.UnmarshalBinary(data); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("Got %v expected %v", err, ErrUnsupportedVersion)
+	}
+}
+
+func BenchmarkInsert(b *testing.B) {
+	f := New(b.N)
+	keys := make([][]byte, b.N)
+	for i := range keys {
+		keys[i] = key(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Insert(keys[i])
+	}
+}
+
+func BenchmarkLookup(b *testing.B) {
+	f := New(1000)
+	data := key(42)
+	f.Insert(data)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Lookup(data)
+	}
+}
@@ -0,0 +1,80 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// The binary form is a fixed header followed by the fingerprints packed at fingerprint bits each,
+// as they are in memory:
+//
+//	version (1) | fingerprint bits (1) | bucket size (4) | buckets (8) | count (8) | victim (1+2+8) | seed (8) | slots
+//
+// Version 1 stored every fingerprint in 2 bytes, it is no longer supported.
+const (
+	version    byte = 2
+	headerSize      = 1 + 1 + 4 + 8 + 8 + 11 + 8
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("cuckoo: unsupported encoding version")
+	ErrInvalidData        = errors.New("cuckoo: invalid encoded data")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	table := f.table[:len(f.table)-2] // without padding
+	buf := make([]byte, headerSize+len(table))
+	buf[0] = version
+	buf[1] = byte(f.fpBits)
+	binary.BigEndian.PutUint32(buf[2:], uint32(f.bucketSize))
+	binary.BigEndian.PutUint64(buf[6:], f.numBuckets)
+	binary.BigEndian.PutUint64(buf[14:], uint64(f.count))
+	if f.victim.used {
+		buf[22] = 1
+	}
+	binary.BigEndian.PutUint16(buf[23:], f.victim.fp)
+	binary.BigEndian.PutUint64(buf[25:], f.victim.i)
+	binary.BigEndian.PutUint64(buf[33:], f.seed)
+	copy(buf[headerSize:], table)
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return ErrInvalidData
+	}
+	if data[0] != version {
+		return ErrUnsupportedVersion
+	}
+	res := Filter{
+		fpBits:     int(data[1]),
+		bucketSize: int(binary.BigEndian.Uint32(data[2:])),
+		numBuckets: binary.BigEndian.Uint64(data[6:]),
+		count:      int(binary.BigEndian.Uint64(data[14:])),
+		victim: victim{
+			used: data[22] == 1,
+			fp:   binary.BigEndian.Uint16(data[23:]),
+			i:    binary.BigEndian.Uint64(data[25:]),
+		},
+		seed: binary.BigEndian.Uint64(data[33:]),
+	}
+	if res.fpBits < 1 || res.fpBits > 16 || res.bucketSize < 1 || res.seed == 0 ||
+		bits.OnesCount64(res.numBuckets) != 1 || res.victim.i >= res.numBuckets {
+		return ErrInvalidData
+	}
+	hi, size := bits.Mul64(res.numBuckets, uint64(res.bucketSize))
+	if hi != 0 || size > uint64(len(data))*8 || res.count < 0 || res.count > int(size)+1 {
+		return ErrInvalidData
+	}
+	n := tableSize(size, res.fpBits)
+	if uint64(len(data)-headerSize) != n-2 {
+		return ErrInvalidData
+	}
+	res.table = make([]byte, n)
+	copy(res.table, data[headerSize:])
+	*f = res
+	return nil
+}
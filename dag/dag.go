@@ -0,0 +1,223 @@
+// Package dag provides strongly connected components and directed acyclic graph utilities
+// on top of graph.Graph.
+//
+// All results follow the insertion order of the graph's vertices and edges, so they are deterministic.
+// An undirected graph is treated as a directed graph with edges both ways.
+package dag
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/bitset"
+	"github.com/zrcoder/dsgo/graph"
+	"github.com/zrcoder/dsgo/heap"
+)
+
+// indexed is a graph with vertices replaced by their insertion indexes
+type indexed[V comparable, W dsgo.Number] struct {
+	vertices []V
+	index    map[V]int
+	adj      [][]arc[W]
+}
+
+type arc[W dsgo.Number] struct {
+	to     int
+	weight W
+}
+
+func newIndexed[V comparable, W dsgo.Number](g *graph.Graph[V, W]) *indexed[V, W] {
+	vertices := g.Vertices()
+	x := &indexed[V, W]{
+		vertices: vertices,
+		index:    make(map[V]int, len(vertices)),
+		adj:      make([][]arc[W], len(vertices)),
+	}
+	for i, v := range vertices {
+		x.index[v] = i
+	}
+	for i, v := range vertices {
+		for _, e := range g.Neighbors(v) {
+			x.adj[i] = append(x.adj[i], arc[W]{to: x.index[e.To], weight: e.Weight})
+		}
+	}
+	return x
+}
+
+func (x *indexed[V, W]) values(ids []int) []V {
+	res := make([]V, len(ids))
+	for i, id := range ids {
+		res[i] = x.vertices[id]
+	}
+	return res
+}
+
+// topoOrder returns the vertex indexes in topological order by Kahn's algorithm,
+// always taking the earliest inserted free vertex next, ok is false if there is a cycle.
+// It is the first of the orders of TopologicalOrders.
+func (x *indexed[V, W]) topoOrder() (order []int, ok bool) {
+	indegree := x.indegrees()
+	free := heap.New[int]()
+	for u, d := range indegree {
+		if d == 0 {
+			free.Push(u)
+		}
+	}
+	order = make([]int, 0, len(x.vertices))
+	for !free.Empty() {
+		u, _ := free.Pop()
+		order = append(order, u)
+		for _, a := range x.adj[u] {
+			if indegree[a.to]--; indegree[a.to] == 0 {
+				free.Push(a.to)
+			}
+		}
+	}
+	return order, len(order) == len(x.vertices)
+}
+
+func (x *indexed[V, W]) indegrees() []int {
+	res := make([]int, len(x.vertices))
+	for _, arcs := range x.adj {
+		for _, a := range arcs {
+			res[a.to]++
+		}
+	}
+	return res
+}
+
+// TopologicalOrders returns an iterator over all topological orders of g,
+// in lexicographic order of vertex insertion indexes. There are none if g has a cycle.
+// Each yielded slice is fresh and may be retained. The number of orders may be exponential.
+func TopologicalOrders[V comparable, W dsgo.Number](g *graph.Graph[V, W]) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		x := newIndexed(g)
+		indegree := x.indegrees()
+		used := make([]bool, len(x.vertices))
+		order := make([]int, 0, len(x.vertices))
+		var walk func() bool
+		walk = func() bool {
+			if len(order) == len(x.vertices) {
+				return yield(x.values(order))
+			}
+			for u := range x.vertices {
+				if used[u] || indegree[u] != 0 {
+					continue
+				}
+				used[u] = true
+				order = append(order, u)
+				for _, a := range x.adj[u] {
+					indegree[a.to]--
+				}
+				ok := walk()
+				for _, a := range x.adj[u] {
+					indegree[a.to]++
+				}
+				order = order[:len(order)-1]
+				used[u] = false
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		if len(x.vertices) > 0 {
+			walk()
+		}
+	}
+}
+
+// LongestPath returns a path of maximum total weight in the acyclic graph g and its weight,
+// a path may be a single vertex of weight 0. Among equally heavy paths it returns the one ending at
+// the vertex first in topological order, reached at each step from the predecessor first in that order,
+// where the topological order always takes the earliest inserted free vertex next.
+// It returns false if g has a cycle.
+// The complexity is O(V log V + E).
+func LongestPath[V comparable, W dsgo.Number](g *graph.Graph[V, W]) ([]V, W, bool) {
+	x := newIndexed(g)
+	order, ok := x.topoOrder()
+	var zero W
+	if !ok {
+		return nil, zero, false
+	}
+	if len(order) == 0 {
+		return nil, zero, true
+	}
+	dist := make([]W, len(x.vertices))
+	prev := make([]int, len(x.vertices))
+	for i := range prev {
+		prev[i] = -1
+	}
+	for _, u := range order {
+		for _, a := range x.adj[u] {
+			if d := dist[u] + a.weight; d > dist[a.to] {
+				dist[a.to] = d
+				prev[a.to] = u
+			}
+		}
+	}
+	end := order[0]
+	for _, u := range order {
+		if dist[u] > dist[end] {
+			end = u
+		}
+	}
+	var path []int
+	for u := end; u != -1; u = prev[u] {
+		path = append(path, u)
+	}
+	slices.Reverse(path)
+	return x.values(path), dist[end], true
+}
+
+// TransitiveReduction returns the graph with the same vertices and reachability as the acyclic graph g
+// and as few edges as possible: an edge u->v is dropped when v is reachable from u by another path.
+// Kept edges retain their weights and insertion order. It returns false if g has a cycle.
+// The complexity is O(V*E/8) using bitsets.
+func TransitiveReduction[V comparable, W dsgo.Number](g *graph.Graph[V, W]) (*graph.Graph[V, W], bool) {
+	x := newIndexed(g)
+	order, ok := x.topoOrder()
+	if !ok {
+		return nil, false
+	}
+	rank := make([]int, len(order))
+	for i, u := range order {
+		rank[u] = i
+	}
+	reach := make([]bitset.BitSet, len(x.vertices))
+	type edgeKey struct{ from, to int }
+	keep := make(map[edgeKey]bool)
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+		reach[u] = bitset.New(len(x.vertices))
+		targets := make([]int, 0, len(x.adj[u]))
+		for _, a := range x.adj[u] {
+			targets = append(targets, a.to)
+		}
+		// a successor reachable through another successor comes later in topological order
+		slices.SortFunc(targets, func(a, b int) int { return rank[a] - rank[b] })
+		for _, v := range targets {
+			if reach[u].Get(v) {
+				continue
+			}
+			keep[edgeKey{u, v}] = true
+			reach[u].Set(v)
+			for j, b := range reach[v] {
+				reach[u][j] |= b
+			}
+		}
+	}
+	res := graph.NewDirected[V, W]()
+	for _, v := range x.vertices {
+		res.AddVertex(v)
+	}
+	for _, e := range g.Edges() {
+		k := edgeKey{x.index[e.From], x.index[e.To]}
+		if keep[k] {
+			res.AddEdge(e.From, e.To, e.Weight)
+			delete(keep, k)
+		}
+	}
+	return res, true
+}
@@ -0,0 +1,179 @@
+package dag
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zrcoder/dsgo/graph"
+)
+
+func newGraph(edges ...[2]string) *graph.Graph[string, int] {
+	g := graph.NewDirected[string, int]()
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], 1)
+	}
+	return g
+}
+
+func edge[V comparable](from, to V) graph.Edge[V, int] {
+	return graph.Edge[V, int]{From: from, To: to, Weight: 1}
+}
+
+func equal[T comparable](a, b [][]T) bool {
+	return slices.EqualFunc(a, b, slices.Equal[[]T])
+}
+
+func sccGraph() *graph.Graph[string, int] {
+	g := newGraph(
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+		[2]string{"b", "d"}, [2]string{"d", "e"}, [2]string{"e", "f"},
+		[2]string{"f", "d"}, [2]string{"g", "f"}, [2]string{"g", "h"},
+		[2]string{"h", "i"}, [2]string{"i", "j"}, [2]string{"j", "g"},
+		[2]string{"j", "k"},
+	)
+	g.AddVertex("z")
+	return g
+}
+
+func TestSCC(t *testing.T) {
+	expectedValue := [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h", "i", "j"}, {"k"}, {"z"}}
+	g := sccGraph()
+	if actualValue := TarjanSCC(g); !equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := KosarajuSCC(g); !equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := TarjanSCC(graph.NewDirected[int, int]()); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+}
+
+func TestSCCDeep(t *testing.T) {
+	const n = 100000
+	g := graph.NewDirected[int, int]()
+	for i := 1; i < n; i++ {
+		g.AddEdge(i-1, i, 0)
+	}
+	g.AddEdge(n-1, 0, 0)
+	if actualValue := TarjanSCC(g); len(actualValue) != 1 || len(actualValue[0]) != n {
+		t.Errorf("Got %d components expected 1", len(actualValue))
+	}
+	if actualValue := KosarajuSCC(g); len(actualValue) != 1 || len(actualValue[0]) != n {
+		t.Errorf("Got %d components expected 1", len(actualValue))
+	}
+}
+
+func TestCondense(t *testing.T) {
+	c := Condense(sccGraph())
+	if c.Graph.Order() != 5 || c.Graph.HasCycle() {
+		t.Errorf("Got %d components", c.Graph.Order())
+	}
+	expectedEdges := []graph.Edge[int, int]{edge(0, 1), edge(2, 1), edge(2, 3)}
+	if actualValue := c.Graph.Edges(); !slices.Equal(actualValue, expectedEdges) {
+		t.Errorf("Got %v expected %v", actualValue, expectedEdges)
+	}
+	if i, ok := c.Component("h"); !ok || i != 2 || !slices.Contains(c.Components[i], "h") {
+		t.Errorf("Got %v expected %v", i, 2)
+	}
+	if _, ok := c.Component("x"); ok {
+		t.Error("expected x to be missing")
+	}
+}
+
+func TestTopologicalOrders(t *testing.T) {
+	g := newGraph([2]string{"a", "c"}, [2]string{"b", "c"}, [2]string{"c", "d"})
+	g.AddVertex("e")
+	var orders [][]string
+	for order := range TopologicalOrders(g) {
+		orders = append(orders, order)
+	}
+	if len(orders) != 10 {
+		t.Fatalf("Got %d orders expected 10", len(orders))
+	}
+	expectedFirst := [][]string{{"a", "b", "c", "d", "e"}, {"a", "b", "c", "e", "d"}, {"a", "b", "e", "c", "d"}}
+	if !equal(orders[:3], expectedFirst) {
+		t.Errorf("Got %v expected %v", orders[:3], expectedFirst)
+	}
+	for order := range TopologicalOrders(g) {
+		if order[0] != "a" {
+			t.Errorf("Got %v", order)
+		}
+		break
+	}
+	g.AddEdge("d", "a", 1)
+	for order := range TopologicalOrders(g) {
+		t.Errorf("unexpected order %v for a cyclic graph", order)
+	}
+}
+
+func TestLongestPath(t *testing.T) {
+	g := graph.NewDirected[string, int]()
+	g.AddEdge("r", "s", 5)
+	g.AddEdge("r", "t", 3)
+	g.AddEdge("s", "t", 2)
+	g.AddEdge("s", "x", 6)
+	g.AddEdge("t", "x", 7)
+	g.AddEdge("t", "y", 4)
+	g.AddEdge("t", "z", 2)
+	g.AddEdge("x", "y", -1)
+	g.AddEdge("x", "z", 1)
+	g.AddEdge("y", "z", -2)
+	path, weight, ok := LongestPath(g)
+	if !ok || weight != 15 || !slices.Equal(path, []string{"r", "s", "t", "x", "z"}) {
+		t.Errorf("Got %v with weight %v", path, weight)
+	}
+	if _, _, ok := LongestPath(sccGraph()); ok {
+		t.Error("expected a cycle")
+	}
+	if path, weight, ok := LongestPath(graph.NewDirected[string, int]()); !ok || path != nil || weight != 0 {
+		t.Errorf("Got %v with weight %v", path, weight)
+	}
+}
+
+func TestLongestPathTies(t *testing.T) {
+	g := graph.NewDirected[string, int]()
+	for _, v := range []string{"a", "y", "b", "c", "d"} {
+		g.AddVertex(v)
+	}
+	g.AddEdge("a", "y", 1)
+	g.AddEdge("y", "b", 1)
+	g.AddEdge("c", "d", 2)
+	x := newIndexed(g)
+	order, _ := x.topoOrder()
+	if actualValue, expectedValue := x.values(order), []string{"a", "y", "b", "c", "d"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for first := range TopologicalOrders(g) {
+		if !slices.Equal(first, x.values(order)) {
+			t.Errorf("Got %v expected %v", first, x.values(order))
+		}
+		break
+	}
+	path, weight, _ := LongestPath(g)
+	if weight != 2 || !slices.Equal(path, []string{"a", "y", "b"}) {
+		t.Errorf("Got %v with weight %v", path, weight)
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	g := newGraph(
+		[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"a", "d"}, [2]string{"a", "e"},
+		[2]string{"b", "d"}, [2]string{"c", "d"}, [2]string{"c", "e"}, [2]string{"d", "e"},
+		[2]string{"a", "b"},
+	)
+	r, ok := TransitiveReduction(g)
+	if !ok {
+		t.Fatal("unexpected cycle")
+	}
+	expectedEdges := []graph.Edge[string, int]{edge("a", "b"), edge("a", "c"), edge("b", "d"), edge("c", "d"), edge("d", "e")}
+	if actualValue := r.Edges(); !slices.Equal(actualValue, expectedEdges) {
+		t.Errorf("Got %v expected %v", actualValue, expectedEdges)
+	}
+	if !slices.Equal(r.Vertices(), g.Vertices()) {
+		t.Errorf("Got %v expected %v", r.Vertices(), g.Vertices())
+	}
+	if _, ok := TransitiveReduction(sccGraph()); ok {
+		t.Error("expected a cycle")
+	}
+}
@@ -0,0 +1,218 @@
+package dag
+
+import (
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/graph"
+)
+
+// TarjanSCC returns the strongly connected components of g found by Tarjan's algorithm.
+// Each component lists its vertices in insertion order, components are ordered by their first vertex.
+// The complexity is O(V+E).
+func TarjanSCC[V comparable, W dsgo.Number](g *graph.Graph[V, W]) [][]V {
+	x := newIndexed(g)
+	return x.components(x.tarjan())
+}
+
+// KosarajuSCC returns the strongly connected components of g found by Kosaraju's algorithm,
+// in the same order as TarjanSCC.
+// The complexity is O(V+E).
+func KosarajuSCC[V comparable, W dsgo.Number](g *graph.Graph[V, W]) [][]V {
+	x := newIndexed(g)
+	return x.components(x.kosaraju())
+}
+
+// tarjan returns the component id of each vertex
+func (x *indexed[V, W]) tarjan() []int {
+	n := len(x.vertices)
+	comp := make([]int, n)
+	index := make([]int, n) // discovery order + 1, 0 for unvisited
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	var stack []int
+	counter, ncomp := 0, 0
+
+	type frame struct{ u, next int }
+	for root := range x.vertices {
+		if index[root] != 0 {
+			continue
+		}
+		counter++
+		index[root], low[root] = counter, counter
+		stack = append(stack, root)
+		onStack[root] = true
+		calls := []frame{{u: root}}
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			u := f.u
+			if f.next < len(x.adj[u]) {
+				v := x.adj[u][f.next].to
+				f.next++
+				switch {
+				case index[v] == 0:
+					counter++
+					index[v], low[v] = counter, counter
+					stack = append(stack, v)
+					onStack[v] = true
+					calls = append(calls, frame{u: v})
+				case onStack[v]:
+					low[u] = min(low[u], index[v])
+				}
+				continue
+			}
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].u
+				low[parent] = min(low[parent], low[u])
+			}
+			if low[u] == index[u] {
+				for {
+					v := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[v] = false
+					comp[v] = ncomp
+					if v == u {
+						break
+					}
+				}
+				ncomp++
+			}
+		}
+	}
+	return comp
+}
+
+// kosaraju returns the component id of each vertex
+func (x *indexed[V, W]) kosaraju() []int {
+	n := len(x.vertices)
+	// first pass: vertices by finishing time
+	visited := make([]bool, n)
+	order := make([]int, 0, n)
+	type frame struct{ u, next int }
+	for root := range x.vertices {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		calls := []frame{{u: root}}
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			if f.next < len(x.adj[f.u]) {
+				v := x.adj[f.u][f.next].to
+				f.next++
+				if !visited[v] {
+					visited[v] = true
+					calls = append(calls, frame{u: v})
+				}
+				continue
+			}
+			order = append(order, f.u)
+			calls = calls[:len(calls)-1]
+		}
+	}
+	// second pass: search the reversed graph by decreasing finishing time
+	reversed := make([][]int, n)
+	for u, arcs := range x.adj {
+		for _, a := range arcs {
+			reversed[a.to] = append(reversed[a.to], u)
+		}
+	}
+	comp := make([]int, n)
+	for i := range comp {
+		comp[i] = -1
+	}
+	ncomp := 0
+	for i := n - 1; i >= 0; i-- {
+		root := order[i]
+		if comp[root] >= 0 {
+			continue
+		}
+		comp[root] = ncomp
+		stack := []int{root}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, v := range reversed[u] {
+				if comp[v] < 0 {
+					comp[v] = ncomp
+					stack = append(stack, v)
+				}
+			}
+		}
+		ncomp++
+	}
+	return comp
+}
+
+// canonical renumbers component ids in order of their first vertex,
+// and returns the member indexes of each component
+func canonical(comp []int) ([]int, [][]int) {
+	renumber := make(map[int]int)
+	var members [][]int
+	res := make([]int, len(comp))
+	for u, c := range comp {
+		id, ok := renumber[c]
+		if !ok {
+			id = len(members)
+			renumber[c] = id
+			members = append(members, nil)
+		}
+		res[u] = id
+		members[id] = append(members[id], u)
+	}
+	return res, members
+}
+
+func (x *indexed[V, W]) components(comp []int) [][]V {
+	_, members := canonical(comp)
+	res := make([][]V, len(members))
+	for i, ids := range members {
+		res[i] = x.values(ids)
+	}
+	return res
+}
+
+// Condensation is the directed acyclic graph obtained by contracting each strongly connected component
+type Condensation[V comparable, W dsgo.Number] struct {
+	// Graph has the component indexes as vertices, for every pair of components joined by edges
+	// it keeps the first such edge in insertion order
+	Graph *graph.Graph[int, W]
+	// Components lists the vertices of each component as returned by TarjanSCC
+	Components [][]V
+	index      map[V]int
+}
+
+// Component returns the index of v's component, ok is false if v is not in the graph
+func (c *Condensation[V, W]) Component(v V) (i int, ok bool) {
+	i, ok = c.index[v]
+	return
+}
+
+// Condense returns the condensation of g, which is always acyclic.
+// The complexity is O(V+E).
+func Condense[V comparable, W dsgo.Number](g *graph.Graph[V, W]) *Condensation[V, W] {
+	x := newIndexed(g)
+	comp, members := canonical(x.tarjan())
+	res := &Condensation[V, W]{
+		Graph:      graph.NewDirected[int, W](),
+		Components: make([][]V, len(members)),
+		index:      make(map[V]int, len(x.vertices)),
+	}
+	for i, ids := range members {
+		res.Components[i] = x.values(ids)
+		res.Graph.AddVertex(i)
+	}
+	for u, v := range x.vertices {
+		res.index[v] = comp[u]
+	}
+	type pair struct{ from, to int }
+	seen := make(map[pair]bool)
+	for _, e := range g.Edges() {
+		p := pair{res.index[e.From], res.index[e.To]}
+		if p.from == p.to || seen[p] {
+			continue
+		}
+		seen[p] = true
+		res.Graph.AddEdge(p.from, p.to, e.Weight)
+	}
+	return res
+}
@@ -0,0 +1,15 @@
+package delayqueue
+
+import "time"
+
+// Clock tells the time to a Queue, tests can inject a fake one to control time
+type Clock interface {
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,225 @@
+// Package delayqueue implements a queue whose items become available at a deadline, as for retry scheduling.
+//
+// The items are kept in a heap ordered by deadline, and each item knows its index in the heap,
+// so Cancel and Reschedule fix the heap in place in O(log n), as pq.PQ does.
+package delayqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zrcoder/dsgo/internal/heap"
+)
+
+// Item is a handle to a value pushed into a Queue
+type Item[T any] struct {
+	value    T
+	deadline time.Time
+	seq      uint64 // keeps the items with equal deadlines in scheduling order
+	index    int    // index in the heap, -1 once taken or cancelled
+	q        *Queue[T]
+}
+
+func (it *Item[T]) Value() T            { return it.value }
+func (it *Item[T]) Deadline() time.Time { return it.deadline }
+
+// Queue is a delay queue safe for concurrent use
+type Queue[T any] struct {
+	mu      sync.Mutex
+	clock   Clock
+	items   []*Item[T] // a heap ordered by deadline
+	seq     uint64
+	changed chan struct{} // closed and replaced to wake the waiting Takes
+}
+
+type Option[T any] func(q *Queue[T])
+
+// WithClock makes the queue tell the time with c instead of the system clock
+func WithClock[T any](c Clock) Option[T] {
+	return func(q *Queue[T]) {
+		q.clock = c
+	}
+}
+
+func New[T any](ops ...Option[T]) *Queue[T] {
+	q := &Queue[T]{
+		clock:   realClock{},
+		changed: make(chan struct{}),
+	}
+	for _, op := range ops {
+		op(q)
+	}
+	return q
+}
+
+// Push adds value to be available at deadline, and returns its handle.
+// The complexity is O(log n) where n is the size of the queue.
+func (q *Queue[T]) Push(value T, deadline time.Time) *Item[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.seq++
+	it := &Item[T]{value: value, deadline: deadline, seq: q.seq, index: len(q.items), q: q}
+	heap.Push(q, it)
+	q.wake(it)
+	return it
+}
+
+// PushAfter adds value to be available after delay
+func (q *Queue[T]) PushAfter(value T, delay time.Duration) *Item[T] {
+	return q.Push(value, q.clock.Now().Add(delay))
+}
+
+// Cancel removes the item from the queue,
+// it returns false if the item belongs to another queue, or was already taken or cancelled.
+// The complexity is O(log n) where n is the size of the queue.
+func (q *Queue[T]) Cancel(it *Item[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if it.q != q || it.index < 0 {
+		return false
+	}
+	top := it.index == 0
+	heap.RemoveIndex(q, it.index)
+	if top {
+		q.broadcast()
+	}
+	return true
+}
+
+// Reschedule moves the item to a new deadline,
+// it returns false if the item belongs to another queue, or was already taken or cancelled.
+// The complexity is O(log n) where n is the size of the queue.
+func (q *Queue[T]) Reschedule(it *Item[T], deadline time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if it.q != q || it.index < 0 {
+		return false
+	}
+	top := it.index == 0
+	q.seq++
+	it.deadline, it.seq = deadline, q.seq
+	heap.FixIndex(q, it.index)
+	if top {
+		q.broadcast()
+	} else {
+		q.wake(it)
+	}
+	return true
+}
+
+// Take removes and returns the item with the earliest deadline, blocking until that deadline passes.
+// It returns the context's error if ctx is done first.
+func (q *Queue[T]) Take(ctx context.Context) (value T, err error) {
+	for {
+		q.mu.Lock()
+		changed := q.changed
+		var timer <-chan time.Time
+		if len(q.items) > 0 {
+			d := q.items[0].deadline.Sub(q.clock.Now())
+			if d <= 0 {
+				value = q.take()
+				q.mu.Unlock()
+				return value, nil
+			}
+			timer = q.clock.After(d)
+		}
+		q.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return value, ctx.Err()
+		case <-changed:
+		case <-timer:
+		}
+	}
+}
+
+// TryTake removes and returns the item with the earliest deadline if that deadline has passed, it never blocks
+func (q *Queue[T]) TryTake() (value T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 || q.items[0].deadline.After(q.clock.Now()) {
+		return value, false
+	}
+	return q.take(), true
+}
+
+// Next returns the earliest deadline in the queue
+func (q *Queue[T]) Next() (deadline time.Time, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return
+	}
+	return q.items[0].deadline, true
+}
+
+// Len returns the number of queued items
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Clear removes all items, their handles can no longer be cancelled or rescheduled
+func (q *Queue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, it := range q.items {
+		it.index = -1
+	}
+	clear(q.items)
+	q.items = q.items[:0]
+	q.broadcast()
+}
+
+// take pops the item with the earliest deadline, the queue must not be empty
+func (q *Queue[T]) take() T {
+	it := heap.Pop[*Item[T]](q)
+	if len(q.items) > 0 {
+		// the other Takes wait for the next deadline now
+		q.broadcast()
+	}
+	return it.value
+}
+
+// wake wakes the waiting Takes if it became the item with the earliest deadline
+func (q *Queue[T]) wake(it *Item[T]) {
+	if it.index == 0 {
+		q.broadcast()
+	}
+}
+
+func (q *Queue[T]) broadcast() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// implements internal/heap.Interface
+
+func (q *Queue[T]) LenX() int { return len(q.items) }
+
+func (q *Queue[T]) LessX(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if c := a.deadline.Compare(b.deadline); c != 0 {
+		return c < 0
+	}
+	return a.seq < b.seq
+}
+
+func (q *Queue[T]) SwapX(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+func (q *Queue[T]) PushX(it *Item[T]) { q.items = append(q.items, it) }
+
+func (q *Queue[T]) PopX() *Item[T] {
+	n := len(q.items)
+	it := q.items[n-1]
+	q.items[n-1] = nil // avoid memory leak
+	q.items = q.items[:n-1]
+	it.index = -1
+	return it
+}
@@ -0,0 +1,261 @@
+package delayqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, waiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiters = append(waiters, w)
+		} else {
+			w.ch <- c.now
+		}
+	}
+	c.waiters = waiters
+}
+
+// wait blocks until someone waits on the clock
+func (c *fakeClock) wait() {
+	for {
+		c.mu.Lock()
+		n := len(c.waiters)
+		c.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTryTake(t *testing.T) {
+	clock := newFakeClock()
+	q := New(WithClock[string](clock))
+	q.PushAfter("c", 3*time.Second)
+	q.PushAfter("a", time.Second)
+	q.PushAfter("b", 2*time.Second)
+	q.PushAfter("b2", 2*time.Second)
+	if actualValue := q.Len(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if _, ok := q.TryTake(); ok {
+		t.Error("expected nothing to be ready")
+	}
+	if deadline, ok := q.Next(); !ok || !deadline.Equal(clock.Now().Add(time.Second)) {
+		t.Errorf("Got %v expected %v", deadline, clock.Now().Add(time.Second))
+	}
+	clock.Advance(2 * time.Second)
+	for _, expected := range []string{"a", "b", "b2"} {
+		if v, ok := q.TryTake(); v != expected || !ok {
+			t.Errorf("Got %v expected %v", v, expected)
+		}
+	}
+	if _, ok := q.TryTake(); ok {
+		t.Error("expected c not to be ready")
+	}
+	clock.Advance(time.Second)
+	if v, ok := q.TryTake(); v != "c" || !ok {
+		t.Errorf("Got %v expected %v", v, "c")
+	}
+	if _, ok := q.Next(); ok || q.Len() != 0 {
+		t.Error("expected an empty queue")
+	}
+}
+
+func TestCancelReschedule(t *testing.T) {
+	clock := newFakeClock()
+	q := New(WithClock[int](clock))
+	now := clock.Now()
+	items := make([]*Item[int], 5)
+	for i := range items {
+		items[i] = q.Push(i, now.Add(time.Duration(i+1)*time.Second))
+	}
+	if !q.Cancel(items[0]) || q.Cancel(items[0]) {
+		t.Error("unexpected Cancel result")
+	}
+	if !q.Reschedule(items[1], now.Add(10*time.Second)) {
+		t.Error("expected item 1 to be rescheduled")
+	}
+	if !q.Reschedule(items[4], now) {
+		t.Error("expected item 4 to be rescheduled")
+	}
+	if actualValue := q.Len(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if deadline, _ := q.Next(); !deadline.Equal(now) {
+		t.Errorf("Got %v expected %v", deadline, now)
+	}
+	clock.Advance(10 * time.Second)
+	var actual []int
+	for {
+		v, ok := q.TryTake()
+		if !ok {
+			break
+		}
+		actual = append(actual, v)
+	}
+	expected := []int{4, 2, 3, 1}
+	if len(actual) != len(expected) {
+		t.Fatalf("Got %v expected %v", actual, expected)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Got %v expected %v", actual, expected)
+		}
+	}
+	if q.Reschedule(items[2], now) || q.Cancel(items[3]) {
+		t.Error("expected taken items to be neither rescheduled nor cancelled")
+	}
+	if items[1].Value() != 1 || !items[1].Deadline().Equal(now.Add(10*time.Second)) {
+		t.Errorf("unexpected item %v at %v", items[1].Value(), items[1].Deadline())
+	}
+}
+
+func TestForeignItem(t *testing.T) {
+	clock := newFakeClock()
+	a, b := New(WithClock[int](clock)), New(WithClock[int](clock))
+	now := clock.Now()
+	a.Push(1, now.Add(time.Second))
+	a.Push(2, now.Add(2*time.Second))
+	other := b.Push(3, now.Add(3*time.Second))
+	if a.Cancel(other) || a.Reschedule(other, now) {
+		t.Error("expected an item of another queue to be refused")
+	}
+	if actualValue := a.Len(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if deadline, _ := b.Next(); !deadline.Equal(now.Add(3 * time.Second)) {
+		t.Errorf("Got %v expected %v", deadline, now.Add(3*time.Second))
+	}
+	if !b.Cancel(other) || b.Len() != 0 {
+		t.Error("expected the item to be cancelled by its own queue")
+	}
+}
+
+func TestTake(t *testing.T) {
+	clock := newFakeClock()
+	q := New(WithClock[int](clock))
+	q.PushAfter(1, time.Minute)
+	done := make(chan int)
+	go func() {
+		v, err := q.Take(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		done <- v
+	}()
+	clock.wait()
+	clock.Advance(30 * time.Second)
+	select {
+	case v := <-done:
+		t.Fatalf("Take returned %v before the deadline", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+	clock.Advance(30 * time.Second)
+	if v := <-done; v != 1 {
+		t.Errorf("Got %v expected %v", v, 1)
+	}
+}
+
+func TestTakeWakesOnPush(t *testing.T) {
+	clock := newFakeClock()
+	q := New(WithClock[int](clock))
+	q.PushAfter(1, time.Hour)
+	done := make(chan int)
+	go func() {
+		v, _ := q.Take(context.Background())
+		done <- v
+	}()
+	clock.wait()
+	q.PushAfter(2, 0)
+	if v := <-done; v != 2 {
+		t.Errorf("Got %v expected %v", v, 2)
+	}
+}
+
+func TestTakeContext(t *testing.T) {
+	q := New[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Got %v expected %v", err, context.DeadlineExceeded)
+	}
+	it := q.PushAfter(1, time.Hour)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Got %v expected %v", err, context.DeadlineExceeded)
+	}
+	q.Reschedule(it, time.Now())
+	if v, err := q.Take(context.Background()); v != 1 || err != nil {
+		t.Errorf("Got %v, %v expected %v", v, err, 1)
+	}
+}
+
+func TestClear(t *testing.T) {
+	q := New[int]()
+	it := q.PushAfter(1, 0)
+	q.PushAfter(2, 0)
+	q.Clear()
+	if _, ok := q.TryTake(); ok || q.Len() != 0 {
+		t.Error("expected an empty queue")
+	}
+	if q.Cancel(it) {
+		t.Error("expected a cleared item not to be cancelled")
+	}
+}
+
+func TestRescheduleBounded(t *testing.T) {
+	clock := newFakeClock()
+	q := New(WithClock[int](clock))
+	a := q.PushAfter(1, time.Second)
+	b := q.PushAfter(2, 2*time.Second)
+	for i := 0; i < 100000; i++ {
+		q.Reschedule(a, clock.Now().Add(time.Duration(i%7)*time.Second))
+		q.Reschedule(b, clock.Now().Add(time.Duration(i%5)*time.Second))
+	}
+	if actualValue := len(q.items); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	q.Cancel(a)
+	if actualValue := len(q.items); actualValue != 1 || q.Len() != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
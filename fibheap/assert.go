@@ -0,0 +1,56 @@
+package fibheap
+
+import (
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+)
+
+var _ dsgo.Container[int] = (*Heap[int])(nil)
+
+// Len returns the size of the heap.
+// The complexity is O(1)
+func (h *Heap[T]) Len() int { return h.size }
+
+// Empty returns if the heap is empty.
+// The complexity is O(1)
+func (h *Heap[T]) Empty() bool { return h.Len() == 0 }
+
+// Values returns the sorted values in the heap
+func (h *Heap[T]) Values() []T {
+	res := make([]T, 0, h.size)
+	h.each(func(n *Node[T]) { res = append(res, n.value) })
+	slices.SortStableFunc(res, h.cmp)
+	return res
+}
+
+// Clear clears and init the heap, the nodes of the heap can no longer be decreased or deleted
+func (h *Heap[T]) Clear() {
+	h.owner.heap = nil
+	h.owner = &owner[T]{heap: h}
+	h.min = nil
+	h.size = 0
+}
+
+// each calls f on every node of the heap
+func (h *Heap[T]) each(f func(n *Node[T])) {
+	if h.min == nil {
+		return
+	}
+	stack := []*Node[T]{h.min}
+	for len(stack) > 0 {
+		first := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n := first
+		for {
+			f(n)
+			if n.child != nil {
+				stack = append(stack, n.child)
+			}
+			n = n.right
+			if n == first {
+				break
+			}
+		}
+	}
+}
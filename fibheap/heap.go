@@ -0,0 +1,304 @@
+/*
+Package fibheap implements a Fibonacci heap.
+
+A Fibonacci heap is a list of heap-ordered trees. Push and Meld just add trees to the root list, in O(1).
+Pop removes the minimum root and links roots of the same degree until all degrees differ, in O(log n) amortized.
+DecreaseKey cuts the node off to the root list, and cascades the cut to ancestors that already lost a child,
+which keeps the trees bushy and the operation O(1) amortized.
+
+Push returns a *Node handle to the value, for DecreaseKey and Delete.
+It suits algorithms with many more decrease-key than pop operations, such as Dijkstra and Prim on dense graphs.
+*/
+package fibheap
+
+import (
+	"cmp"
+
+	"github.com/zrcoder/dsgo"
+)
+
+// Node is a handle to a value in a Heap
+type Node[T any] struct {
+	value       T
+	parent      *Node[T]
+	child       *Node[T] // any child, the children form a circular list
+	left, right *Node[T] // siblings in the circular list
+	degree      int      // number of children
+	mark        bool     // lost a child since it became a child itself
+	live        bool     // false once popped or deleted
+	owner       *owner[T]
+}
+
+// owner identifies the heap a node belongs to.
+// Meld forwards the owner of the melded heap to the owner of the receiver,
+// so that its nodes change hands in O(1), and gives the melded heap a new owner.
+type owner[T any] struct {
+	heap *Heap[T]  // nil once forwarded or cleared
+	next *owner[T] // set once forwarded
+}
+
+// heap returns the heap n belongs to, or nil if it was cleared.
+// It halves the forwarding path on the way, so the lookups are O(1) amortized.
+func (n *Node[T]) heap() *Heap[T] {
+	o := n.owner
+	for o.next != nil {
+		if o.next.next != nil {
+			o.next = o.next.next
+		}
+		o = o.next
+	}
+	n.owner = o
+	return o.heap
+}
+
+// Value returns the node's value
+func (n *Node[T]) Value() T { return n.value }
+
+type Heap[T any] struct {
+	cmp   dsgo.Comparator[T]
+	min   *Node[T] // root with the minimum value, the roots form a circular list
+	size  int
+	owner *owner[T]
+	// scratch space of consolidate, kept to save allocations
+	roots    []*Node[T]
+	byDegree []*Node[T]
+}
+
+func New[T cmp.Ordered]() *Heap[T] {
+	return NewWith[T](cmp.Compare[T])
+}
+
+func NewWith[T any](cmp dsgo.Comparator[T]) *Heap[T] {
+	h := &Heap[T]{cmp: cmp}
+	h.owner = &owner[T]{heap: h}
+	return h
+}
+
+// Push pushes the element value onto the heap, and returns its node.
+// The complexity is O(1).
+func (h *Heap[T]) Push(value T) *Node[T] {
+	n := &Node[T]{value: value, live: true, owner: h.owner}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+// Pop removes and returns the peek element from the heap.
+// The complexity is O(log n) amortized where n is the size of the heap.
+func (h *Heap[T]) Pop() (value T, ok bool) {
+	if h.min == nil {
+		return
+	}
+	return h.removeMin().value, true
+}
+
+// Peek returns the peek value of the heap
+// The complexity is O(1)
+func (h *Heap[T]) Peek() (value T, ok bool) {
+	if h.min == nil {
+		return
+	}
+	return h.min.value, true
+}
+
+// DecreaseKey replaces the value of node n with a value not greater than it.
+// It returns false if n does not belong to the heap, was already popped or deleted, or if value is greater than n's value.
+// The complexity is O(1) amortized.
+func (h *Heap[T]) DecreaseKey(n *Node[T], value T) bool {
+	if !h.owns(n) || h.cmp(value, n.value) > 0 {
+		return false
+	}
+	n.value = value
+	if p := n.parent; p != nil && h.cmp(n.value, p.value) < 0 {
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+	if h.cmp(n.value, h.min.value) < 0 {
+		h.min = n
+	}
+	return true
+}
+
+// Delete removes node n.
+// It returns false if n does not belong to the heap, or was already popped or deleted.
+// The complexity is O(log n) amortized where n is the size of the heap.
+func (h *Heap[T]) Delete(n *Node[T]) bool {
+	if !h.owns(n) {
+		return false
+	}
+	// as if n's value were decreased to minus infinity
+	if p := n.parent; p != nil {
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+	h.min = n
+	h.removeMin()
+	return true
+}
+
+// Meld moves all elements of other into the heap, leaving other empty.
+// The nodes of other now belong to h.
+// Both heaps should order elements the same way, the result follows h's comparator.
+// The complexity is O(1).
+func (h *Heap[T]) Meld(other *Heap[T]) {
+	if other == h || other.min == nil {
+		return
+	}
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		splice(h.min, other.min)
+		if h.cmp(other.min.value, h.min.value) < 0 {
+			h.min = other.min
+		}
+	}
+	h.size += other.size
+	other.min, other.size = nil, 0
+	other.owner.heap, other.owner.next = nil, h.owner
+	other.owner = &owner[T]{heap: other}
+}
+
+// owns reports whether n is a live node of the heap
+func (h *Heap[T]) owns(n *Node[T]) bool {
+	return n.live && n.heap() == h
+}
+
+// addRoot adds the single node n to the root list
+func (h *Heap[T]) addRoot(n *Node[T]) {
+	n.parent = nil
+	n.mark = false
+	if h.min == nil {
+		n.left, n.right = n, n
+		h.min = n
+		return
+	}
+	splice(h.min, n)
+	if h.cmp(n.value, h.min.value) < 0 {
+		h.min = n
+	}
+}
+
+func (h *Heap[T]) removeMin() *Node[T] {
+	z := h.min
+	for c := z.child; c != nil; c = z.child {
+		// move the children to the root list one by one
+		if c.right == c {
+			z.child = nil
+		} else {
+			z.child = c.right
+			unlink(c)
+		}
+		c.parent = nil
+		c.mark = false
+		splice(z, c)
+	}
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		unlink(z)
+		h.consolidate()
+	}
+	z.live = false
+	z.left, z.right, z.child = nil, nil, nil
+	z.degree = 0
+	h.size--
+	return z
+}
+
+// consolidate links the roots of the same degree until all degrees differ, and finds the new minimum
+func (h *Heap[T]) consolidate() {
+	roots, byDegree := h.roots[:0], h.byDegree
+	r := h.min
+	for {
+		roots = append(roots, r)
+		r = r.right
+		if r == h.min {
+			break
+		}
+	}
+	for _, x := range roots {
+		unlink(x)
+		for {
+			d := x.degree
+			for d >= len(byDegree) {
+				byDegree = append(byDegree, nil)
+			}
+			y := byDegree[d]
+			if y == nil {
+				byDegree[d] = x
+				break
+			}
+			byDegree[d] = nil
+			if h.cmp(y.value, x.value) < 0 {
+				x, y = y, x
+			}
+			h.link(y, x)
+		}
+	}
+	h.min = nil
+	for i, x := range byDegree {
+		if x != nil {
+			h.addRoot(x)
+			byDegree[i] = nil
+		}
+	}
+	clear(roots)
+	h.roots, h.byDegree = roots, byDegree
+}
+
+// link makes the detached root y a child of x
+func (h *Heap[T]) link(y, x *Node[T]) {
+	y.parent = x
+	y.mark = false
+	if x.child == nil {
+		y.left, y.right = y, y
+		x.child = y
+	} else {
+		splice(x.child, y)
+	}
+	x.degree++
+}
+
+// cut moves n from its parent's children to the root list
+func (h *Heap[T]) cut(n *Node[T]) {
+	p := n.parent
+	if n.right == n {
+		p.child = nil
+	} else {
+		if p.child == n {
+			p.child = n.right
+		}
+		unlink(n)
+	}
+	p.degree--
+	h.addRoot(n)
+}
+
+// cascadingCut cuts the ancestors that lose a second child, up to a root
+func (h *Heap[T]) cascadingCut(n *Node[T]) {
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if !n.mark {
+			n.mark = true
+			return
+		}
+		h.cut(n)
+	}
+}
+
+// splice joins the circular list of b into the one of a, right after a
+func splice[T any](a, b *Node[T]) {
+	aRight, bLeft := a.right, b.left
+	a.right = b
+	b.left = a
+	bLeft.right = aRight
+	aRight.left = bLeft
+}
+
+// unlink removes n from its circular list, leaving it a list of its own
+func unlink[T any](n *Node[T]) {
+	n.left.right = n.right
+	n.right.left = n.left
+	n.left, n.right = n, n
+}
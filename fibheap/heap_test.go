@@ -0,0 +1,265 @@
+package fibheap
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/heapx"
+)
+
+func TestHeapPush(t *testing.T) {
+	heap := New[int]()
+
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	if actualValue, expectedValue := heap.Values(), []int{1, 2, 3}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := heap.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestHeapPop(t *testing.T) {
+	heap := NewWith(dsgo.Reverse(cmp.Compare[int]))
+
+	heap.Push(1)
+	heap.Push(3)
+	heap.Push(2)
+	heap.Pop()
+
+	if actualValue, ok := heap.Peek(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 0 || ok {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestHeapDecreaseKey(t *testing.T) {
+	heap := New[int]()
+	nodes := make([]*Node[int], 10)
+	for i := range nodes {
+		nodes[i] = heap.Push(i * 10)
+	}
+	heap.Pop() // consolidates the others into trees
+	if !heap.DecreaseKey(nodes[9], 5) {
+		t.Error("expected node 9 to be decreased")
+	}
+	if heap.DecreaseKey(nodes[8], 90) {
+		t.Error("expected an increase to be refused")
+	}
+	if heap.DecreaseKey(nodes[0], -1) {
+		t.Error("expected a popped node to be refused")
+	}
+	if actualValue, _ := heap.Peek(); actualValue != 5 || nodes[9].Value() != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if !heap.Delete(nodes[5]) || heap.Delete(nodes[5]) {
+		t.Error("unexpected Delete result")
+	}
+	if actualValue, expectedValue := heap.Values(), []int{5, 10, 20, 30, 40, 60, 70, 80}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	heap.Clear()
+	if heap.DecreaseKey(nodes[1], 0) || heap.Delete(nodes[2]) || !heap.Empty() {
+		t.Error("expected the nodes of a cleared heap to be refused")
+	}
+}
+
+func TestHeapMeld(t *testing.T) {
+	a, b := New[int](), New[int]()
+	for _, v := range []int{5, 1, 9} {
+		a.Push(v)
+	}
+	nodes := make([]*Node[int], 0, 4)
+	for _, v := range []int{4, 8, 2, 7} {
+		nodes = append(nodes, b.Push(v))
+	}
+	b.Pop()
+	a.Meld(b)
+	if actualValue, expectedValue := a.Values(), []int{1, 4, 5, 7, 8, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := b.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if !a.DecreaseKey(nodes[1], 0) {
+		t.Error("expected a melded node to be decreased")
+	}
+	if actualValue, _ := a.Pop(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	a.Meld(a)
+	if actualValue := a.Len(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+}
+
+func TestHeapForeignNode(t *testing.T) {
+	a, b, c := New[int](), New[int](), New[int]()
+	a.Push(5)
+	nb := b.Push(3)
+	nc := c.Push(4)
+	if a.DecreaseKey(nb, 1) || a.Delete(nb) {
+		t.Error("expected a node of another heap to be refused")
+	}
+	if actualValue, expectedValue := b.Values(), []int{3}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// c's nodes follow b, then a
+	b.Meld(c)
+	a.Meld(b)
+	if b.DecreaseKey(nc, 2) || c.Delete(nc) {
+		t.Error("expected a melded away node to be refused by its old heaps")
+	}
+	nb2 := b.Push(6)
+	if a.Delete(nb2) {
+		t.Error("expected a node pushed after the meld to stay with its heap")
+	}
+	if !a.DecreaseKey(nc, 2) || !a.Delete(nb) {
+		t.Error("expected the melded nodes to be accepted")
+	}
+	if actualValue, expectedValue := a.Values(), []int{2, 5}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := b.Values(), []int{6}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	heap := New[int]()
+	live := map[*Node[int]]bool{}
+	nodes := []*Node[int]{}
+	expected := map[*Node[int]]int{}
+	for i := 0; i < 20000; i++ {
+		switch op := r.Intn(10); {
+		case op < 4:
+			v := r.Intn(100000)
+			n := heap.Push(v)
+			nodes = append(nodes, n)
+			live[n] = true
+			expected[n] = v
+		case op < 7 && len(nodes) > 0:
+			n := nodes[r.Intn(len(nodes))]
+			v := expected[n] - r.Intn(1000)
+			if heap.DecreaseKey(n, v) != live[n] {
+				t.Fatalf("unexpected DecreaseKey result for live %v", live[n])
+			}
+			if live[n] {
+				expected[n] = v
+			}
+		case op < 8 && len(nodes) > 0:
+			n := nodes[r.Intn(len(nodes))]
+			if heap.Delete(n) != live[n] {
+				t.Fatalf("unexpected Delete result for live %v", live[n])
+			}
+			live[n] = false
+		default:
+			v, ok := heap.Pop()
+			least, found := 0, false
+			for n, l := range live {
+				if l && (!found || expected[n] < least) {
+					least, found = expected[n], true
+				}
+			}
+			if ok != found || v != least {
+				t.Fatalf("Got %v, %v expected %v, %v", v, ok, least, found)
+			}
+			for n, l := range live {
+				if l && expected[n] == v {
+					live[n] = false
+					break
+				}
+			}
+		}
+	}
+	count := 0
+	for _, l := range live {
+		if l {
+			count++
+		}
+	}
+	if heap.Len() != count {
+		t.Errorf("Got %v expected %v", heap.Len(), count)
+	}
+	prev := 0
+	for i := 0; !heap.Empty(); i++ {
+		v, _ := heap.Pop()
+		if i > 0 && v < prev {
+			t.Fatalf("popped %v after %v", v, prev)
+		}
+		prev = v
+	}
+}
+
+type item struct {
+	key int
+}
+
+// BenchmarkDecreaseKeyFibHeap pushes size items, decreases random keys 4*size times, then pops everything,
+// like Dijkstra on a dense graph
+func BenchmarkDecreaseKeyFibHeap(b *testing.B) {
+	size := 10000
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		heap := New[int]()
+		nodes := make([]*Node[int], size)
+		for j := range nodes {
+			nodes[j] = heap.Push(size * 10)
+		}
+		for j := 0; j < 4*size; j++ {
+			n := nodes[r.Intn(size)]
+			heap.DecreaseKey(n, n.Value()-r.Intn(10))
+		}
+		for !heap.Empty() {
+			heap.Pop()
+		}
+	}
+}
+
+// BenchmarkDecreaseKeyHeapx runs the same workload on heapx, with Update after decreasing a key
+func BenchmarkDecreaseKeyHeapx(b *testing.B) {
+	size := 10000
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		heap := heapx.NewWith(func(a, b *item) int { return cmp.Compare(a.key, b.key) })
+		items := make([]*item, size)
+		for j := range items {
+			items[j] = &item{key: size * 10}
+			heap.Push(items[j])
+		}
+		for j := 0; j < 4*size; j++ {
+			it := items[r.Intn(size)]
+			it.key -= r.Intn(10)
+			heap.Update(it)
+		}
+		for !heap.Empty() {
+			heap.Pop()
+		}
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/zrcoder/dsgo
 
-go 1.21
+// Go 1.23 is required for the iter package: graph.BFS and graph.DFS, dag.TopologicalOrders
+// and topk.TopKSeq/BottomKSeq produce or consume iter.Seq.
+go 1.23
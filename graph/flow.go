@@ -0,0 +1,142 @@
+package graph
+
+import "github.com/zrcoder/dsgo"
+
+// Flow is a maximum flow from a source to a sink, with a minimum cut
+type Flow[V comparable, W dsgo.Number] struct {
+	// Value is the total flow, equal to the capacity of the minimum cut
+	Value W
+	// Edges are the edges carrying flow in insertion order, each oriented along its flow with Weight the flow on it
+	Edges []Edge[V, W]
+	// SourceSide are the vertices still reachable from the source in the residual graph, in insertion order
+	SourceSide []V
+	// Cut are the edges crossing from the source side to the sink side, with their capacities
+	Cut []Edge[V, W]
+}
+
+// residual is the residual graph of Dinic's algorithm,
+// arcs 2i and 2i+1 are the forward and backward arcs of edge i, so arc a is paired with a^1
+type residual[W dsgo.Number] struct {
+	to    []int
+	cap   []W
+	adj   [][]int
+	level []int
+	next  []int // next arc to try for each vertex in the current phase
+}
+
+// MaxFlow computes the maximum flow from source to sink with Dinic's algorithm,
+// edge weights are capacities and must be non-negative, undirected edges carry flow either way.
+// The complexity is O(V^2 * E).
+func (g *Graph[V, W]) MaxFlow(source, sink V) *Flow[V, W] {
+	s, ok1 := g.index[source]
+	t, ok2 := g.index[sink]
+	res := &Flow[V, W]{}
+	if !ok1 || !ok2 || s == t {
+		if ok1 {
+			res.SourceSide = []V{source}
+		}
+		return res
+	}
+	r := g.residual()
+	for r.bfs(s, t) {
+		r.next = make([]int, len(g.vertices))
+		for {
+			var zero W
+			f := r.dfs(s, t, zero, true)
+			if f == zero {
+				break
+			}
+			res.Value += f
+		}
+	}
+	for i, e := range g.edges {
+		switch f := e.weight - r.cap[2*i]; {
+		case f > 0:
+			res.Edges = append(res.Edges, Edge[V, W]{From: g.vertices[e.from], To: g.vertices[e.to], Weight: f})
+		case f < 0:
+			res.Edges = append(res.Edges, Edge[V, W]{From: g.vertices[e.to], To: g.vertices[e.from], Weight: -f})
+		}
+	}
+	for u, v := range g.vertices {
+		if r.level[u] >= 0 {
+			res.SourceSide = append(res.SourceSide, v)
+		}
+	}
+	for _, e := range g.edges {
+		from, to := r.level[e.from] >= 0, r.level[e.to] >= 0
+		switch {
+		case from && !to:
+			res.Cut = append(res.Cut, g.edge(e))
+		case !from && to && !g.directed:
+			res.Cut = append(res.Cut, Edge[V, W]{From: g.vertices[e.to], To: g.vertices[e.from], Weight: e.weight})
+		}
+	}
+	return res
+}
+
+func (g *Graph[V, W]) residual() *residual[W] {
+	r := &residual[W]{
+		to:    make([]int, 2*len(g.edges)),
+		cap:   make([]W, 2*len(g.edges)),
+		adj:   make([][]int, len(g.vertices)),
+		level: make([]int, len(g.vertices)),
+	}
+	for i, e := range g.edges {
+		if e.weight < 0 {
+			panic("graph: MaxFlow requires non-negative capacities")
+		}
+		r.to[2*i], r.cap[2*i] = e.to, e.weight
+		r.to[2*i+1] = e.from
+		if !g.directed {
+			r.cap[2*i+1] = e.weight
+		}
+		r.adj[e.from] = append(r.adj[e.from], 2*i)
+		r.adj[e.to] = append(r.adj[e.to], 2*i+1)
+	}
+	return r
+}
+
+// bfs levels the vertices by distance from s in the residual graph, it returns true if t is reachable
+func (r *residual[W]) bfs(s, t int) bool {
+	for i := range r.level {
+		r.level[i] = -1
+	}
+	r.level[s] = 0
+	q := []int{s}
+	for len(q) > 0 {
+		u := q[0]
+		q = q[1:]
+		for _, a := range r.adj[u] {
+			if v := r.to[a]; r.cap[a] > 0 && r.level[v] < 0 {
+				r.level[v] = r.level[u] + 1
+				q = append(q, v)
+			}
+		}
+	}
+	return r.level[t] >= 0
+}
+
+// dfs pushes an augmenting flow of at most limit along the level graph, unlimited for the first call
+func (r *residual[W]) dfs(u, t int, limit W, unlimited bool) W {
+	if u == t {
+		return limit
+	}
+	var zero W
+	for ; r.next[u] < len(r.adj[u]); r.next[u]++ {
+		a := r.adj[u][r.next[u]]
+		v := r.to[a]
+		if r.cap[a] <= 0 || r.level[v] != r.level[u]+1 {
+			continue
+		}
+		push := r.cap[a]
+		if !unlimited {
+			push = min(push, limit)
+		}
+		if f := r.dfs(v, t, push, false); f > zero {
+			r.cap[a] -= f
+			r.cap[a^1] += f
+			return f
+		}
+	}
+	return zero
+}
@@ -0,0 +1,155 @@
+// Package graph implements generic directed and undirected graphs with weighted edges,
+// and the classic algorithms on them.
+//
+// Vertices and edges are kept in insertion order, and every algorithm visits them in that order,
+// so results are deterministic.
+package graph
+
+import "github.com/zrcoder/dsgo"
+
+// Edge is a weighted edge, for undirected graphs From and To are interchangeable
+type Edge[V comparable, W dsgo.Number] struct {
+	From, To V
+	Weight   W
+}
+
+// Graph is a directed or undirected graph with weighted edges, parallel edges and self-loops are allowed
+type Graph[V comparable, W dsgo.Number] struct {
+	directed bool
+	vertices []V
+	index    map[V]int
+	edges    []edge[W]
+	adj      [][]int // ids of the edges leaving each vertex
+}
+
+type edge[W dsgo.Number] struct {
+	from, to int
+	weight   W
+}
+
+// other returns the endpoint of e opposite to v
+func (e edge[W]) other(v int) int {
+	if e.from == v {
+		return e.to
+	}
+	return e.from
+}
+
+func NewDirected[V comparable, W dsgo.Number]() *Graph[V, W] {
+	return &Graph[V, W]{directed: true, index: make(map[V]int)}
+}
+
+func NewUndirected[V comparable, W dsgo.Number]() *Graph[V, W] {
+	return &Graph[V, W]{index: make(map[V]int)}
+}
+
+// Directed returns true if the graph is directed
+func (g *Graph[V, W]) Directed() bool { return g.directed }
+
+// Order returns the number of vertices
+func (g *Graph[V, W]) Order() int { return len(g.vertices) }
+
+// Size returns the number of edges
+func (g *Graph[V, W]) Size() int { return len(g.edges) }
+
+// AddVertex adds the vertex v, it returns false if v is already present
+func (g *Graph[V, W]) AddVertex(v V) bool {
+	if _, ok := g.index[v]; ok {
+		return false
+	}
+	g.id(v)
+	return true
+}
+
+// AddEdge adds an edge from one vertex to another with the given weight, adding missing vertices.
+func (g *Graph[V, W]) AddEdge(from, to V, weight W) {
+	u, v := g.id(from), g.id(to)
+	id := len(g.edges)
+	g.edges = append(g.edges, edge[W]{from: u, to: v, weight: weight})
+	g.adj[u] = append(g.adj[u], id)
+	if !g.directed && u != v {
+		g.adj[v] = append(g.adj[v], id)
+	}
+}
+
+// HasVertex returns true if v is in the graph
+func (g *Graph[V, W]) HasVertex(v V) bool {
+	_, ok := g.index[v]
+	return ok
+}
+
+// HasEdge returns true if there is an edge from one vertex to the other
+func (g *Graph[V, W]) HasEdge(from, to V) bool {
+	u, ok1 := g.index[from]
+	v, ok2 := g.index[to]
+	if !ok1 || !ok2 {
+		return false
+	}
+	for _, id := range g.adj[u] {
+		if g.edges[id].other(u) == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Vertices returns all vertices in insertion order
+func (g *Graph[V, W]) Vertices() []V {
+	return append([]V(nil), g.vertices...)
+}
+
+// Edges returns all edges in insertion order
+func (g *Graph[V, W]) Edges() []Edge[V, W] {
+	res := make([]Edge[V, W], len(g.edges))
+	for i, e := range g.edges {
+		res[i] = g.edge(e)
+	}
+	return res
+}
+
+// Neighbors returns the edges leaving v in insertion order, each with From set to v
+func (g *Graph[V, W]) Neighbors(v V) []Edge[V, W] {
+	u, ok := g.index[v]
+	if !ok {
+		return nil
+	}
+	res := make([]Edge[V, W], len(g.adj[u]))
+	for i, id := range g.adj[u] {
+		e := g.edges[id]
+		res[i] = Edge[V, W]{From: v, To: g.vertices[e.other(u)], Weight: e.weight}
+	}
+	return res
+}
+
+// Degree returns the number of edges leaving v
+func (g *Graph[V, W]) Degree(v V) int {
+	u, ok := g.index[v]
+	if !ok {
+		return 0
+	}
+	return len(g.adj[u])
+}
+
+func (g *Graph[V, W]) edge(e edge[W]) Edge[V, W] {
+	return Edge[V, W]{From: g.vertices[e.from], To: g.vertices[e.to], Weight: e.weight}
+}
+
+// id returns the index of v, adding v if needed
+func (g *Graph[V, W]) id(v V) int {
+	if i, ok := g.index[v]; ok {
+		return i
+	}
+	i := len(g.vertices)
+	g.index[v] = i
+	g.vertices = append(g.vertices, v)
+	g.adj = append(g.adj, nil)
+	return i
+}
+
+// arcs calls fn with the target and weight of each edge leaving u
+func (g *Graph[V, W]) arcs(u int, fn func(v int, weight W)) {
+	for _, id := range g.adj[u] {
+		e := g.edges[id]
+		fn(e.other(u), e.weight)
+	}
+}
@@ -0,0 +1,286 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraph(t *testing.T) {
+	g := NewUndirected[string, int]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 2)
+	g.AddVertex("d")
+	if g.AddVertex("a") {
+		t.Error("expected a to be present")
+	}
+	if g.Order() != 4 || g.Size() != 2 || g.Directed() {
+		t.Errorf("Got order %v size %v", g.Order(), g.Size())
+	}
+	if !g.HasEdge("b", "a") || g.HasEdge("b", "c") || g.HasEdge("x", "a") {
+		t.Error("unexpected HasEdge result")
+	}
+	if !g.HasVertex("d") || g.HasVertex("x") {
+		t.Error("unexpected HasVertex result")
+	}
+	if actualValue, expectedValue := g.Vertices(), []string{"a", "b", "c", "d"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := g.Edges(), []Edge[string, int]{{"a", "b", 1}, {"a", "c", 2}}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := g.Neighbors("b"), []Edge[string, int]{{"b", "a", 1}}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if g.Degree("a") != 2 || g.Degree("d") != 0 || g.Neighbors("x") != nil {
+		t.Error("unexpected degree")
+	}
+
+	d := NewDirected[int, int]()
+	d.AddEdge(1, 2, 0)
+	if !d.HasEdge(1, 2) || d.HasEdge(2, 1) || d.Degree(2) != 0 {
+		t.Error("unexpected directed edges")
+	}
+}
+
+func TestTraversal(t *testing.T) {
+	g := NewUndirected[int, int]()
+	for _, e := range [][2]int{{1, 2}, {1, 3}, {2, 4}, {3, 4}, {4, 5}, {6, 7}} {
+		g.AddEdge(e[0], e[1], 1)
+	}
+	if actualValue, expectedValue := slices.Collect(g.BFS(1)), []int{1, 2, 3, 4, 5}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := slices.Collect(g.DFS(1)), []int{1, 2, 4, 3, 5}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := slices.Collect(g.BFS(100)); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	for v := range g.DFS(1) {
+		if v == 4 {
+			break
+		}
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g := NewDirected[string, int]()
+	g.AddEdge("s", "a", 7)
+	g.AddEdge("s", "b", 2)
+	g.AddEdge("b", "a", 3)
+	g.AddEdge("a", "t", 1)
+	g.AddEdge("b", "t", 8)
+	g.AddVertex("x")
+	p := g.Dijkstra("s")
+	if d, ok := p.DistTo("t"); !ok || d != 6 {
+		t.Errorf("Got %v expected %v", d, 6)
+	}
+	if actualValue, expectedValue := p.PathTo("t"), []string{"s", "b", "a", "t"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := p.PathTo("s"), []string{"s"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, ok := p.DistTo("x"); ok || p.PathTo("x") != nil || p.PathTo("y") != nil {
+		t.Error("expected x to be unreachable")
+	}
+	if _, ok := g.Dijkstra("y").DistTo("s"); ok {
+		t.Error("expected nothing reachable from a missing source")
+	}
+}
+
+func TestBellmanFord(t *testing.T) {
+	g := NewDirected[string, float64]()
+	g.AddEdge("s", "a", 4)
+	g.AddEdge("s", "b", 5)
+	g.AddEdge("b", "a", -3)
+	g.AddEdge("a", "t", 1.5)
+	p, ok := g.BellmanFord("s")
+	if !ok {
+		t.Fatal("unexpected negative cycle")
+	}
+	if d, ok := p.DistTo("t"); !ok || d != 3.5 {
+		t.Errorf("Got %v expected %v", d, 3.5)
+	}
+	if actualValue, expectedValue := p.PathTo("t"), []string{"s", "b", "a", "t"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	g.AddEdge("t", "b", -4)
+	if _, ok := g.BellmanFord("s"); ok {
+		t.Error("expected a negative cycle")
+	}
+	if _, ok := g.BellmanFord("x"); !ok {
+		t.Error("unexpected negative cycle from a missing source")
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirected[string, int]()
+	g.AddEdge("shirt", "tie", 0)
+	g.AddEdge("tie", "jacket", 0)
+	g.AddEdge("pants", "shoes", 0)
+	g.AddEdge("pants", "belt", 0)
+	g.AddEdge("belt", "jacket", 0)
+	g.AddEdge("socks", "shoes", 0)
+	order, ok := g.TopologicalSort()
+	expectedValue := []string{"shirt", "tie", "pants", "belt", "jacket", "socks", "shoes"}
+	if !ok || !slices.Equal(order, expectedValue) {
+		t.Errorf("Got %v expected %v", order, expectedValue)
+	}
+	h := NewDirected[string, int]()
+	for _, v := range []string{"a", "b", "c", "d"} {
+		h.AddVertex(v)
+	}
+	h.AddEdge("a", "b", 0)
+	if order, _ := h.TopologicalSort(); !slices.Equal(order, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Got %v expected %v", order, []string{"a", "b", "c", "d"})
+	}
+	if g.HasCycle() {
+		t.Error("unexpected cycle")
+	}
+	g.AddEdge("jacket", "shirt", 0)
+	if _, ok := g.TopologicalSort(); ok || !g.HasCycle() {
+		t.Error("expected a cycle")
+	}
+
+	u := NewUndirected[int, int]()
+	u.AddVertex(2)
+	u.AddVertex(1)
+	if order, ok := u.TopologicalSort(); !ok || !slices.Equal(order, []int{2, 1}) {
+		t.Errorf("Got %v %v expected %v true", order, ok, []int{2, 1})
+	}
+	u.AddEdge(1, 2, 0)
+	if order, ok := u.TopologicalSort(); ok || order != nil {
+		t.Errorf("Got %v %v expected [] false", order, ok)
+	}
+	u.AddEdge(2, 3, 0)
+	if u.HasCycle() {
+		t.Error("unexpected cycle")
+	}
+	u.AddEdge(3, 1, 0)
+	if !u.HasCycle() {
+		t.Error("expected a cycle")
+	}
+}
+
+func TestConnectedComponents(t *testing.T) {
+	g := NewDirected[int, int]()
+	for _, e := range [][2]int{{1, 2}, {3, 4}, {5, 1}, {4, 6}} {
+		g.AddEdge(e[0], e[1], 0)
+	}
+	g.AddVertex(7)
+	expectedValue := [][]int{{1, 2, 5}, {3, 4, 6}, {7}}
+	if actualValue := g.ConnectedComponents(); !slices.EqualFunc(actualValue, expectedValue, slices.Equal[[]int]) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func mstGraph() *Graph[string, int] {
+	g := NewUndirected[string, int]()
+	for _, e := range []Edge[string, int]{
+		{"a", "b", 4}, {"a", "h", 8}, {"b", "c", 8}, {"b", "h", 11}, {"c", "d", 7},
+		{"c", "f", 4}, {"c", "i", 2}, {"d", "e", 9}, {"d", "f", 14}, {"e", "f", 10},
+		{"f", "g", 2}, {"g", "h", 1}, {"g", "i", 6}, {"h", "i", 7},
+	} {
+		g.AddEdge(e.From, e.To, e.Weight)
+	}
+	g.AddEdge("x", "y", 3)
+	return g
+}
+
+func TestKruskal(t *testing.T) {
+	tree := mstGraph().Kruskal()
+	if tree.Weight != 40 || len(tree.Edges) != 9 {
+		t.Errorf("Got weight %v with %d edges expected weight 40 with 9 edges", tree.Weight, len(tree.Edges))
+	}
+	expectedValue := []Edge[string, int]{{"g", "h", 1}, {"c", "i", 2}, {"f", "g", 2}, {"x", "y", 3}, {"a", "b", 4}}
+	if !slices.Equal(tree.Edges[:5], expectedValue) {
+		t.Errorf("Got %v expected %v", tree.Edges[:5], expectedValue)
+	}
+}
+
+func TestPrim(t *testing.T) {
+	tree := mstGraph().Prim()
+	if tree.Weight != 40 || len(tree.Edges) != 9 {
+		t.Errorf("Got weight %v with %d edges expected weight 40 with 9 edges", tree.Weight, len(tree.Edges))
+	}
+	expectedValue := []Edge[string, int]{
+		{"a", "b", 4}, {"a", "h", 8}, {"h", "g", 1}, {"g", "f", 2}, {"f", "c", 4},
+		{"c", "i", 2}, {"c", "d", 7}, {"d", "e", 9}, {"x", "y", 3},
+	}
+	if !slices.Equal(tree.Edges, expectedValue) {
+		t.Errorf("Got %v expected %v", tree.Edges, expectedValue)
+	}
+
+	d := NewDirected[int, float64]()
+	d.AddEdge(1, 2, 5)
+	d.AddEdge(3, 1, 1)
+	d.AddEdge(3, 2, 2)
+	if tree := d.Prim(); tree.Weight != 3 || tree.Weight != d.Kruskal().Weight {
+		t.Errorf("Got %v expected %v", tree.Weight, 3)
+	}
+}
+
+func TestMaxFlow(t *testing.T) {
+	g := NewDirected[string, int]()
+	for _, e := range []Edge[string, int]{
+		{"s", "v1", 16}, {"s", "v2", 13}, {"v2", "v1", 4}, {"v1", "v3", 12}, {"v3", "v2", 9},
+		{"v2", "v4", 14}, {"v4", "v3", 7}, {"v3", "t", 20}, {"v4", "t", 4},
+	} {
+		g.AddEdge(e.From, e.To, e.Weight)
+	}
+	flow := g.MaxFlow("s", "t")
+	if flow.Value != 23 {
+		t.Errorf("Got %v expected %v", flow.Value, 23)
+	}
+	cut := 0
+	for _, e := range flow.Cut {
+		cut += e.Weight
+	}
+	if cut != flow.Value {
+		t.Errorf("cut capacity %v differs from flow %v", cut, flow.Value)
+	}
+	if expectedValue := []string{"s", "v1", "v2", "v4"}; !slices.Equal(flow.SourceSide, expectedValue) {
+		t.Errorf("Got %v expected %v", flow.SourceSide, expectedValue)
+	}
+	balance := map[string]int{}
+	for _, e := range flow.Edges {
+		if e.Weight <= 0 {
+			t.Errorf("unexpected flow %v", e)
+		}
+		balance[e.From] -= e.Weight
+		balance[e.To] += e.Weight
+	}
+	for v, b := range balance {
+		if (v == "s" && b != -23) || (v == "t" && b != 23) || (v != "s" && v != "t" && b != 0) {
+			t.Errorf("flow is not conserved at %v: %v", v, b)
+		}
+	}
+
+	if flow := g.MaxFlow("t", "s"); flow.Value != 0 || len(flow.Edges) != 0 {
+		t.Errorf("Got %v expected %v", flow.Value, 0)
+	}
+	if flow := g.MaxFlow("s", "x"); flow.Value != 0 {
+		t.Errorf("Got %v expected %v", flow.Value, 0)
+	}
+}
+
+func TestMaxFlowUndirected(t *testing.T) {
+	g := NewUndirected[int, float64]()
+	g.AddEdge(1, 2, 1.5)
+	g.AddEdge(3, 2, 2)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(4, 3, 2.5)
+	flow := g.MaxFlow(1, 4)
+	if flow.Value != 2.5 {
+		t.Errorf("Got %v expected %v", flow.Value, 2.5)
+	}
+	expectedValue := []Edge[int, float64]{{1, 2, 1.5}, {2, 3, 1.5}, {1, 3, 1}, {3, 4, 2.5}}
+	if !slices.Equal(flow.Edges, expectedValue) {
+		t.Errorf("Got %v expected %v", flow.Edges, expectedValue)
+	}
+	if expectedValue := []Edge[int, float64]{{1, 2, 1.5}, {1, 3, 1}}; !slices.Equal(flow.Cut, expectedValue) {
+		t.Errorf("Got %v expected %v", flow.Cut, expectedValue)
+	}
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/heapx"
+	"github.com/zrcoder/dsgo/unionfind"
+)
+
+// SpanningTree is a minimum spanning forest, one tree per connected component
+type SpanningTree[V comparable, W dsgo.Number] struct {
+	Edges  []Edge[V, W]
+	Weight W
+}
+
+func (t *SpanningTree[V, W]) add(e Edge[V, W]) {
+	t.Edges = append(t.Edges, e)
+	t.Weight += e.Weight
+}
+
+// Kruskal computes a minimum spanning forest by adding the lightest edges that join two components,
+// edge directions are ignored. The edges are returned in the order they were chosen, lightest first.
+// The complexity is O(E log E).
+func (g *Graph[V, W]) Kruskal() *SpanningTree[V, W] {
+	ids := make([]int, len(g.edges))
+	for i := range ids {
+		ids[i] = i
+	}
+	slices.SortStableFunc(ids, func(a, b int) int {
+		return cmp.Compare(g.edges[a].weight, g.edges[b].weight)
+	})
+	uf := unionfind.NewUnionFind(len(g.vertices))
+	res := &SpanningTree[V, W]{}
+	for _, id := range ids {
+		e := g.edges[id]
+		if uf.Join(e.from, e.to) {
+			res.add(g.edge(e))
+		}
+		if uf.Count() == 1 {
+			break
+		}
+	}
+	return res
+}
+
+type primItem[W dsgo.Number] struct {
+	v    int
+	edge int // the lightest edge connecting v to the tree
+	key  W   // weight of that edge
+}
+
+// Prim computes a minimum spanning forest by growing a tree from a vertex, always adding the lightest edge leaving it,
+// edge directions are ignored. Trees are grown from vertices in insertion order,
+// and the edges are returned in the order they were chosen, each oriented away from its tree's first vertex.
+// The complexity is O(E log V), using a heap with decrease-key.
+func (g *Graph[V, W]) Prim() *SpanningTree[V, W] {
+	incident := g.adj
+	if g.directed {
+		incident = make([][]int, len(g.vertices))
+		for id, e := range g.edges {
+			incident[e.from] = append(incident[e.from], id)
+			if e.to != e.from {
+				incident[e.to] = append(incident[e.to], id)
+			}
+		}
+	}
+	items := make([]*primItem[W], len(g.vertices))
+	inTree := make([]bool, len(g.vertices))
+	h := heapx.NewWith(func(a, b *primItem[W]) int {
+		if c := cmp.Compare(a.key, b.key); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.edge, b.edge)
+	})
+	res := &SpanningTree[V, W]{}
+	for root := range g.vertices {
+		if inTree[root] {
+			continue
+		}
+		h.Push(&primItem[W]{v: root, edge: -1})
+		for !h.Empty() {
+			item, _ := h.Pop()
+			u := item.v
+			inTree[u] = true
+			if item.edge >= 0 {
+				e := g.edges[item.edge]
+				res.add(Edge[V, W]{From: g.vertices[e.other(u)], To: g.vertices[u], Weight: e.weight})
+			}
+			for _, id := range incident[u] {
+				e := g.edges[id]
+				v := e.other(u)
+				if inTree[v] {
+					continue
+				}
+				switch it := items[v]; {
+				case it == nil:
+					items[v] = &primItem[W]{v: v, edge: id, key: e.weight}
+					h.Push(items[v])
+				case e.weight < it.key:
+					it.edge, it.key = id, e.weight
+					h.Update(it)
+				}
+			}
+		}
+	}
+	return res
+}
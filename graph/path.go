@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/heap"
+)
+
+// Paths holds the shortest paths from a source vertex
+type Paths[V comparable, W dsgo.Number] struct {
+	g       *Graph[V, W]
+	dist    []W
+	prev    []int // previous vertex on the shortest path, -1 for the source and unreached vertices
+	reached []bool
+}
+
+func (g *Graph[V, W]) newPaths(source V) (*Paths[V, W], int, bool) {
+	n := len(g.vertices)
+	p := &Paths[V, W]{g: g, dist: make([]W, n), prev: make([]int, n), reached: make([]bool, n)}
+	for i := range p.prev {
+		p.prev[i] = -1
+	}
+	s, ok := g.index[source]
+	if ok {
+		p.reached[s] = true
+	}
+	return p, s, ok
+}
+
+// DistTo returns the length of the shortest path to v, ok is false if v is unreachable
+func (p *Paths[V, W]) DistTo(v V) (dist W, ok bool) {
+	i, ok := p.g.index[v]
+	if !ok || i >= len(p.reached) || !p.reached[i] {
+		return dist, false
+	}
+	return p.dist[i], true
+}
+
+// PathTo returns the vertices on the shortest path from the source to v, nil if v is unreachable
+func (p *Paths[V, W]) PathTo(v V) []V {
+	i, ok := p.g.index[v]
+	if !ok || i >= len(p.reached) || !p.reached[i] {
+		return nil
+	}
+	var res []V
+	// the bound only matters for the meaningless paths left by a negative cycle
+	for ; i != -1 && len(res) <= len(p.prev); i = p.prev[i] {
+		res = append(res, p.g.vertices[i])
+	}
+	slices.Reverse(res)
+	return res
+}
+
+type distItem[W dsgo.Number] struct {
+	v    int
+	dist W
+}
+
+// Dijkstra computes the shortest paths from source, all weights must be non-negative.
+// The complexity is O((V+E) log V).
+func (g *Graph[V, W]) Dijkstra(source V) *Paths[V, W] {
+	p, s, ok := g.newPaths(source)
+	if !ok {
+		return p
+	}
+	h := heap.NewWith(func(a, b distItem[W]) int { return cmp.Compare(a.dist, b.dist) })
+	h.Push(distItem[W]{v: s})
+	done := make([]bool, len(g.vertices))
+	for !h.Empty() {
+		cur, _ := h.Pop()
+		u := cur.v
+		if done[u] {
+			continue
+		}
+		done[u] = true
+		g.arcs(u, func(v int, weight W) {
+			if weight < 0 {
+				panic("graph: Dijkstra requires non-negative weights")
+			}
+			if d := p.dist[u] + weight; !p.reached[v] || d < p.dist[v] {
+				p.reached[v] = true
+				p.dist[v] = d
+				p.prev[v] = u
+				h.Push(distItem[W]{v: v, dist: d})
+			}
+		})
+	}
+	return p
+}
+
+// BellmanFord computes the shortest paths from source, weights may be negative.
+// It returns false if a negative cycle is reachable from source, then the paths are meaningless.
+// Note that in an undirected graph a negative edge is itself a negative cycle.
+// The complexity is O(V*E).
+func (g *Graph[V, W]) BellmanFord(source V) (*Paths[V, W], bool) {
+	p, _, ok := g.newPaths(source)
+	if !ok {
+		return p, true
+	}
+	relax := func() bool {
+		changed := false
+		for u := range g.vertices {
+			if !p.reached[u] {
+				continue
+			}
+			g.arcs(u, func(v int, weight W) {
+				if d := p.dist[u] + weight; !p.reached[v] || d < p.dist[v] {
+					p.reached[v] = true
+					p.dist[v] = d
+					p.prev[v] = u
+					changed = true
+				}
+			})
+		}
+		return changed
+	}
+	for i := 1; i < len(g.vertices); i++ {
+		if !relax() {
+			return p, true
+		}
+	}
+	return p, !relax()
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"github.com/zrcoder/dsgo/heap"
+	"github.com/zrcoder/dsgo/unionfind"
+)
+
+// TopologicalSort returns the vertices ordered so that every edge goes from an earlier vertex to a later one,
+// among the valid orders it is the one that always picks the earliest inserted free vertex next.
+// It returns false if the graph has a cycle, which includes any edge of an undirected graph.
+// An undirected graph without edges gives its vertices in insertion order.
+// The complexity is O(V log V + E).
+func (g *Graph[V, W]) TopologicalSort() ([]V, bool) {
+	if !g.directed {
+		if len(g.edges) > 0 {
+			return nil, false
+		}
+		return g.Vertices(), true
+	}
+	indegree := make([]int, len(g.vertices))
+	for _, e := range g.edges {
+		indegree[e.to]++
+	}
+	free := heap.New[int]() // free vertices by insertion index
+	for u, d := range indegree {
+		if d == 0 {
+			free.Push(u)
+		}
+	}
+	res := make([]V, 0, len(g.vertices))
+	for !free.Empty() {
+		u, _ := free.Pop()
+		res = append(res, g.vertices[u])
+		g.arcs(u, func(v int, _ W) {
+			if indegree[v]--; indegree[v] == 0 {
+				free.Push(v)
+			}
+		})
+	}
+	if len(res) < len(g.vertices) {
+		return nil, false
+	}
+	return res, true
+}
+
+// HasCycle returns true if the graph has a cycle,
+// for an undirected graph self-loops and parallel edges count as cycles
+func (g *Graph[V, W]) HasCycle() bool {
+	if g.directed {
+		_, ok := g.TopologicalSort()
+		return !ok
+	}
+	uf := unionfind.NewUnionFind(len(g.vertices))
+	for _, e := range g.edges {
+		if !uf.Join(e.from, e.to) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConnectedComponents returns the connected components, weakly connected ones for a directed graph.
+// Each component lists its vertices in insertion order, components are ordered by their first vertex.
+// The complexity is O(V+E) with an inverse-Ackermann factor.
+func (g *Graph[V, W]) ConnectedComponents() [][]V {
+	uf := unionfind.NewUnionFind(len(g.vertices))
+	for _, e := range g.edges {
+		uf.Join(e.from, e.to)
+	}
+	group := make(map[int]int, uf.Count()) // root to index in res
+	res := make([][]V, 0, uf.Count())
+	for u, v := range g.vertices {
+		root := uf.Find(u)
+		i, ok := group[root]
+		if !ok {
+			i = len(res)
+			group[root] = i
+			res = append(res, nil)
+		}
+		res[i] = append(res[i], v)
+	}
+	return res
+}
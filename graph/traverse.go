@@ -0,0 +1,62 @@
+package graph
+
+import (
+	"iter"
+
+	"github.com/zrcoder/dsgo/queue"
+)
+
+// BFS returns an iterator over the vertices reachable from start in breadth-first order
+func (g *Graph[V, W]) BFS(start V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		s, ok := g.index[start]
+		if !ok {
+			return
+		}
+		visited := make([]bool, len(g.vertices))
+		visited[s] = true
+		q := queue.New[int]()
+		q.Enqueue(s)
+		for !q.Empty() {
+			u, _ := q.Dequeue()
+			if !yield(g.vertices[u]) {
+				return
+			}
+			for _, id := range g.adj[u] {
+				if v := g.edges[id].other(u); !visited[v] {
+					visited[v] = true
+					q.Enqueue(v)
+				}
+			}
+		}
+	}
+}
+
+// DFS returns an iterator over the vertices reachable from start in depth-first preorder
+func (g *Graph[V, W]) DFS(start V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		s, ok := g.index[start]
+		if !ok {
+			return
+		}
+		visited := make([]bool, len(g.vertices))
+		stack := []int{s}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[u] {
+				continue
+			}
+			visited[u] = true
+			if !yield(g.vertices[u]) {
+				return
+			}
+			// push in reverse so the first neighbor is visited first
+			for i := len(g.adj[u]) - 1; i >= 0; i-- {
+				if v := g.edges[g.adj[u][i]].other(u); !visited[v] {
+					stack = append(stack, v)
+				}
+			}
+		}
+	}
+}
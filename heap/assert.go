@@ -16,13 +16,14 @@ func (h *Heap[T]) Len() int { return len(h.data) }
 // The complexity is O(1)
 func (h *Heap[T]) Empty() bool { return h.Len() == 0 }
 
-// Values returns the sorted values in the heap
-func (h *Heap[T]) Values() []T {
-	res := make([]T, h.Len())
-	tmp := h.clone()
-	for i := range res {
-		res[i], _ = tmp.Pop()
-	}
+// Values returns the sorted values in the heap, the same as SortedValues
+func (h *Heap[T]) Values() []T { return h.SortedValues() }
+
+// SortedValues returns the values in the heap in pop order, leaving the heap unchanged.
+// The complexity is O(nlog n) where n is the size of the heap.
+func (h *Heap[T]) SortedValues() []T {
+	res := slices.Clone(h.data)
+	slices.SortFunc(res, h.cmp)
 	return res
 }
 
@@ -32,9 +33,12 @@ func (h *Heap[T]) Clear() {
 	h.data = h.data[:0]
 }
 
-func (h *Heap[T]) clone() *Heap[T] {
+// Clone returns an independent copy of the heap, with the same comparator and arity.
+// The complexity is O(n) where n is the size of the heap.
+func (h *Heap[T]) Clone() *Heap[T] {
 	return &Heap[T]{
-		cmp:  h.cmp,
-		data: slices.Clone(h.data),
+		cmp:   h.cmp,
+		data:  slices.Clone(h.data),
+		arity: h.arity,
 	}
 }
@@ -8,8 +8,9 @@ import (
 )
 
 type Heap[T any] struct {
-	cmp  dsgo.Comparator[T]
-	data []T
+	cmp   dsgo.Comparator[T]
+	data  []T
+	arity int
 }
 
 func New[T cmp.Ordered](ops ...Option[T]) *Heap[T] {
@@ -50,7 +51,20 @@ func (h *Heap[T]) Peek() (value T, ok bool) {
 	return h.data[0], true
 }
 
-// implements internal/heap.Interface
+// Meld moves all elements of other into the heap, leaving other empty.
+// Both heaps should order elements the same way, the result follows h's comparator.
+// The complexity is O(n+m) where n and m are the sizes of the heaps.
+func (h *Heap[T]) Meld(other *Heap[T]) {
+	if other == h {
+		return
+	}
+	h.data = append(h.data, other.data...)
+	heap.Init(h)
+	other.Clear()
+}
+
+// implements internal/heap.Interface and internal/heap.Arity
+func (h *Heap[T]) ArityX() int         { return h.arity }
 func (h *Heap[T]) LenX() int           { return len(h.data) }
 func (h *Heap[T]) LessX(i, j int) bool { return h.cmp(h.data[i], h.data[j]) < 0 }
 func (h *Heap[T]) SwapX(i, j int)      { h.data[i], h.data[j] = h.data[j], h.data[i] }
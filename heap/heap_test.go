@@ -1,6 +1,7 @@
 package heap
 
 import (
+	"fmt"
 	"math/rand"
 	"slices"
 	"testing"
@@ -97,6 +98,72 @@ func TestBinaryHeapRandom(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapMeld(t *testing.T) {
+	a, b := New[int](), New[int]()
+	for _, v := range []int{5, 1, 9} {
+		a.Push(v)
+	}
+	for _, v := range []int{4, 8, 2, 7} {
+		b.Push(v)
+	}
+	a.Meld(b)
+	if actualValue, expectedValue := a.Values(), []int{1, 2, 4, 5, 7, 8, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := b.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	a.Meld(a)
+	if actualValue := a.Len(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+}
+
+func TestBinaryHeapClone(t *testing.T) {
+	h := New(WithArity[int](4), WithData([]int{5, 1, 9, 1, 3}))
+	c := h.Clone()
+	if actualValue, expectedValue := c.SortedValues(), []int{1, 1, 3, 5, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := c.ArityX(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	c.Pop()
+	c.Push(0)
+	if actualValue, expectedValue := h.Values(), []int{1, 1, 3, 5, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, _ := c.Peek(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := h.Len(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+}
+
+func TestBinaryHeapArity(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		r := rand.New(rand.NewSource(int64(d)))
+		nums := make([]int, 100)
+		for i := range nums {
+			nums[i] = r.Intn(30)
+		}
+		heap := New(WithData(nums), WithArity[int](d))
+		for i := 0; i < 1000; i++ {
+			heap.Push(r.Intn(30))
+		}
+		expected := heap.Values()
+		if !slices.IsSorted(expected) || len(expected) != 1100 {
+			t.Errorf("arity %d: unsorted values", d)
+		}
+		for i := 0; heap.Len() > 0; i++ {
+			if actualValue, _ := heap.Pop(); actualValue != expected[i] {
+				t.Fatalf("arity %d: Got %v expected %v", d, actualValue, expected[i])
+			}
+		}
+	}
+}
+
 func benchmarkPush(b *testing.B, heap *Heap[int], size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -197,3 +264,37 @@ func BenchmarkBinaryHeapPush100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkPush(b, heap, size)
 }
+
+// BenchmarkArity compares heaps of different arities on a push-heavy workload,
+// where every pop follows four pushes, and on a pop-only drain.
+func BenchmarkArity(b *testing.B) {
+	const size = 100000
+	r := rand.New(rand.NewSource(3))
+	nums := make([]int, size)
+	for i := range nums {
+		nums[i] = r.Int()
+	}
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("PushHeavy-%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				heap := New(WithArity[int](d), WithCapacity[int](size))
+				for j, v := range nums {
+					heap.Push(v)
+					if j%4 == 3 {
+						heap.Pop()
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("Pop-%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				heap := New(WithData(nums), WithArity[int](d))
+				b.StartTimer()
+				for !heap.Empty() {
+					heap.Pop()
+				}
+			}
+		})
+	}
+}
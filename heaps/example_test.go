@@ -0,0 +1,25 @@
+package heaps
+
+import (
+	"cmp"
+	"fmt"
+)
+
+type task struct {
+	name     string
+	priority int
+}
+
+func Example_slice() {
+	tasks := []task{{"write", 3}, {"test", 2}, {"review", 1}}
+	byPriority := func(a, b task) int { return cmp.Compare(a.priority, b.priority) }
+	HeapifySlice(tasks, byPriority)
+	tasks = PushSlice(tasks, task{"deploy", 4}, byPriority)
+	for len(tasks) > 0 {
+		var t task
+		tasks, t = PopSlice(tasks, byPriority)
+		fmt.Printf("%s ", t.name)
+	}
+	// Output:
+	// review test write deploy
+}
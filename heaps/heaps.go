@@ -0,0 +1,88 @@
+// Package heaps provides heap operations for any type that implements heaps.Interface,
+// and for plain slices ordered by a comparator.
+//
+// It is the public face of the generic, d-ary port of "container/heap" used by the heap, heapx and pq packages.
+// Unlike those, it leaves the storage to the caller, so it works on the caller's own slice types.
+package heaps
+
+import "github.com/zrcoder/dsgo/internal/heap"
+
+// The Interface type describes the requirements
+// for a type using the routines in this package.
+// Any type that implements it may be used as a
+// min-heap with the following invariants (established after
+// [Init] has been called or if the data is empty or sorted):
+//
+//	!h.Less(j, i) for 0 <= i < h.Len() and d*i+1 <= j <= d*i+d and j < h.Len()
+//
+// where d is the arity of the heap: 2 unless h implements [Arity].
+//
+// Note that Push and Pop in this interface are for package heaps'
+// implementation to call. To add and remove things from the heap,
+// use [heaps.Push] and [heaps.Pop].
+type Interface[T any] interface {
+	Len() int
+	Less(i, j int) bool
+	Swap(i, j int)
+	Push(x T) // add x as element Len()
+	Pop() T   // remove and return element Len() - 1.
+}
+
+// Arity is implemented by heaps whose nodes have Arity() children instead of 2.
+// The arity must not change while the heap is not empty.
+type Arity interface {
+	Arity() int
+}
+
+// adapter makes an Interface usable by internal/heap, whose method names are suffixed
+// so that they don't collide with the public methods of the heap types built on it
+type adapter[T any] struct {
+	h Interface[T]
+}
+
+func (a adapter[T]) LenX() int           { return a.h.Len() }
+func (a adapter[T]) LessX(i, j int) bool { return a.h.Less(i, j) }
+func (a adapter[T]) SwapX(i, j int)      { a.h.Swap(i, j) }
+func (a adapter[T]) PushX(x T)           { a.h.Push(x) }
+func (a adapter[T]) PopX() T             { return a.h.Pop() }
+func (a adapter[T]) ArityX() int {
+	if h, ok := a.h.(Arity); ok {
+		return h.Arity()
+	}
+	return 2
+}
+
+// Init establishes the heap invariants required by the other routines in this package.
+// Init is idempotent with respect to the heap invariants
+// and may be called whenever the heap invariants may have been invalidated.
+// The complexity is O(n) where n = h.Len().
+func Init[T any](h Interface[T]) {
+	heap.Init[T](adapter[T]{h})
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n) where n = h.Len().
+func Push[T any](h Interface[T], x T) {
+	heap.Push[T](adapter[T]{h}, x)
+}
+
+// Pop removes and returns the minimum element (according to Less) from the heap.
+// The complexity is O(log n) where n = h.Len().
+// Pop is equivalent to [Remove](h, 0).
+func Pop[T any](h Interface[T]) T {
+	return heap.Pop[T](adapter[T]{h})
+}
+
+// Remove removes and returns the element at index i from the heap.
+// The complexity is O(log n) where n = h.Len().
+func Remove[T any](h Interface[T], i int) T {
+	return heap.RemoveIndex[T](adapter[T]{h}, i)
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling [Remove](h, i) followed by a Push of the new value.
+// The complexity is O(log n) where n = h.Len().
+func Fix[T any](h Interface[T], i int) {
+	heap.FixIndex[T](adapter[T]{h}, i)
+}
@@ -0,0 +1,77 @@
+package heaps
+
+import (
+	"math/rand"
+	"testing"
+)
+
+type intHeap struct {
+	s     []int
+	arity int
+}
+
+func (h *intHeap) Len() int           { return len(h.s) }
+func (h *intHeap) Less(i, j int) bool { return h.s[i] < h.s[j] }
+func (h *intHeap) Swap(i, j int)      { h.s[i], h.s[j] = h.s[j], h.s[i] }
+func (h *intHeap) Push(x int)         { h.s = append(h.s, x) }
+func (h *intHeap) Pop() int {
+	n := len(h.s) - 1
+	x := h.s[n]
+	h.s = h.s[:n]
+	return x
+}
+
+type wideHeap struct{ intHeap }
+
+func (h *wideHeap) Arity() int { return h.arity }
+
+func (h *intHeap) verify(t *testing.T, d int) {
+	t.Helper()
+	for i := 1; i < len(h.s); i++ {
+		if p := (i - 1) / d; h.s[i] < h.s[p] {
+			t.Fatalf("heap invariant invalidated [%d] = %v > [%d] = %v", p, h.s[p], i, h.s[i])
+		}
+	}
+}
+
+func TestInterface(t *testing.T) {
+	for _, d := range []int{2, 3, 4} {
+		var h Interface[int]
+		var base *intHeap
+		if d == 2 {
+			base = &intHeap{}
+			h = base
+		} else {
+			w := &wideHeap{intHeap{arity: d}}
+			base, h = &w.intHeap, w
+		}
+		for i := 0; i < 100; i++ {
+			base.s = append(base.s, rand.Intn(1000))
+		}
+		Init(h)
+		base.verify(t, d)
+		for i := 0; i < 50; i++ {
+			Push(h, rand.Intn(1000))
+			base.verify(t, d)
+		}
+		for i := 0; i < 20; i++ {
+			Remove(h, rand.Intn(h.Len()))
+			base.verify(t, d)
+		}
+		for i := 0; i < 20; i++ {
+			j := rand.Intn(h.Len())
+			base.s[j] = rand.Intn(1000)
+			Fix(h, j)
+			base.verify(t, d)
+		}
+		prev := -1
+		for h.Len() > 0 {
+			x := Pop(h)
+			base.verify(t, d)
+			if x < prev {
+				t.Fatalf("arity %d: popped %d after %d", d, x, prev)
+			}
+			prev = x
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package heaps
+
+import (
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/internal/heap"
+)
+
+// sliceHeap is a binary min heap over a slice, Pop shrinks the slice but leaves the popped element in its backing array
+type sliceHeap[T any] struct {
+	s   []T
+	cmp dsgo.Comparator[T]
+}
+
+func (h *sliceHeap[T]) LenX() int           { return len(h.s) }
+func (h *sliceHeap[T]) LessX(i, j int) bool { return h.cmp(h.s[i], h.s[j]) < 0 }
+func (h *sliceHeap[T]) SwapX(i, j int)      { h.s[i], h.s[j] = h.s[j], h.s[i] }
+func (h *sliceHeap[T]) PushX(x T)           { h.s = append(h.s, x) }
+func (h *sliceHeap[T]) PopX() T {
+	n := len(h.s) - 1
+	x := h.s[n]
+	h.s = h.s[:n]
+	return x
+}
+
+// HeapifySlice rearranges s into a min heap ordered by cmp.
+// The complexity is O(n) where n = len(s).
+func HeapifySlice[S ~[]T, T any](s S, cmp dsgo.Comparator[T]) {
+	heap.Init[T](&sliceHeap[T]{s: s, cmp: cmp})
+}
+
+// PushSlice pushes x onto the min heap s ordered by cmp, and returns the grown slice.
+// The complexity is O(log n) where n = len(s).
+func PushSlice[S ~[]T, T any](s S, x T, cmp dsgo.Comparator[T]) S {
+	h := &sliceHeap[T]{s: s, cmp: cmp}
+	heap.Push[T](h, x)
+	return h.s
+}
+
+// PopSlice removes the minimum element from the min heap s ordered by cmp,
+// and returns the shrunk slice and that element. It panics if s is empty.
+// The complexity is O(log n) where n = len(s).
+func PopSlice[S ~[]T, T any](s S, cmp dsgo.Comparator[T]) (S, T) {
+	h := &sliceHeap[T]{s: s, cmp: cmp}
+	x := heap.Pop[T](h)
+	var zero T
+	s[len(h.s)] = zero // release the popped element for the garbage collector
+	return h.s, x
+}
+
+// HeapSort sorts s in ascending order by cmp, in place. It is not stable.
+// The complexity is O(n*log n) where n = len(s).
+func HeapSort[S ~[]T, T any](s S, cmp dsgo.Comparator[T]) {
+	// each Pop of a max heap moves its maximum just past the shrunk heap
+	h := &sliceHeap[T]{s: s, cmp: dsgo.Reverse(cmp)}
+	heap.Init[T](h)
+	for len(h.s) > 1 {
+		heap.Pop[T](h)
+	}
+}
+
+// IsHeap returns true if s is a min heap ordered by cmp.
+// The complexity is O(n) where n = len(s).
+func IsHeap[S ~[]T, T any](s S, cmp dsgo.Comparator[T]) bool {
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i], s[(i-1)/2]) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package heaps
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	s := rand.Perm(100)
+	if IsHeap(s, cmp.Compare[int]) {
+		t.Error("expected a random permutation not to be a heap")
+	}
+	HeapifySlice(s, cmp.Compare[int])
+	if !IsHeap(s, cmp.Compare[int]) {
+		t.Error("expected a heap after HeapifySlice")
+	}
+	for i := 100; i < 150; i++ {
+		s = PushSlice(s, i-125, cmp.Compare[int])
+		if !IsHeap(s, cmp.Compare[int]) {
+			t.Fatalf("expected a heap after pushing %d", i-125)
+		}
+	}
+	if len(s) != 150 {
+		t.Errorf("Got %v expected %v", len(s), 150)
+	}
+	prev := -26
+	for len(s) > 0 {
+		var x int
+		s, x = PopSlice(s, cmp.Compare[int])
+		if !IsHeap(s, cmp.Compare[int]) {
+			t.Fatal("expected a heap after PopSlice")
+		}
+		if x < prev {
+			t.Fatalf("popped %d after %d", x, prev)
+		}
+		prev = x
+	}
+}
+
+func TestPopSliceOrder(t *testing.T) {
+	s := []string{"d", "b", "e", "a", "c"}
+	HeapifySlice(s, cmp.Compare[string])
+	var actual []string
+	for len(s) > 0 {
+		var x string
+		s, x = PopSlice(s, cmp.Compare[string])
+		actual = append(actual, x)
+	}
+	if expected := []string{"a", "b", "c", "d", "e"}; !slices.Equal(actual, expected) {
+		t.Errorf("Got %v expected %v", actual, expected)
+	}
+}
+
+func TestHeapSort(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = rand.Intn(n/2 + 1)
+		}
+		expected := slices.Clone(s)
+		slices.Sort(expected)
+		HeapSort(s, cmp.Compare[int])
+		if !slices.Equal(s, expected) {
+			t.Errorf("Got %v expected %v", s, expected)
+		}
+	}
+	s := []int{3, 1, 2}
+	HeapSort(s, func(a, b int) int { return b - a })
+	if expected := []int{3, 2, 1}; !slices.Equal(s, expected) {
+		t.Errorf("Got %v expected %v", s, expected)
+	}
+}
+
+type durations []int64
+
+func TestNamedSlice(t *testing.T) {
+	s := durations{5, 3, 4}
+	HeapifySlice(s, cmp.Compare[int64])
+	s = PushSlice(s, 1, cmp.Compare[int64])
+	s, x := PopSlice(s, cmp.Compare[int64])
+	if x != 1 || len(s) != 3 || s[0] != 3 {
+		t.Errorf("Got %v, %v expected %v", x, s, 1)
+	}
+}
@@ -19,12 +19,31 @@ func (h *Heap[T]) Len() int {
 // The complexity is O(1)
 func (h *Heap[T]) Empty() bool { return h.Len() == 0 }
 
-// Values returns the sorted values in the heap
-func (h *Heap[T]) Values() []T {
-	res := make([]T, h.Len())
-	tmp := h.clone()
-	for i := range res {
-		res[i], _ = tmp.Pop()
+// Count returns how many copies of value are in the heap.
+// The complexity is O(1)
+func (h *Heap[T]) Count(value T) int {
+	return h.cnt[value]
+}
+
+// Distinct returns the values in the heap without duplicates, in no particular order.
+// The complexity is O(k) where k is the number of distinct values.
+func (h *Heap[T]) Distinct() []T {
+	return slices.Clone(h.data)
+}
+
+// Values returns the sorted values in the heap, the same as SortedValues
+func (h *Heap[T]) Values() []T { return h.SortedValues() }
+
+// SortedValues returns the values in the heap in pop order, duplicates included, leaving the heap unchanged.
+// The complexity is O(n + klog k) where k is the number of distinct values.
+func (h *Heap[T]) SortedValues() []T {
+	distinct := h.Distinct()
+	slices.SortFunc(distinct, h.cmp)
+	res := make([]T, 0, h.size)
+	for _, v := range distinct {
+		for range h.cnt[v] {
+			res = append(res, v)
+		}
 	}
 	return res
 }
@@ -38,12 +57,15 @@ func (h *Heap[T]) Clear() {
 	h.size = 0
 }
 
-func (h *Heap[T]) clone() *Heap[T] {
+// Clone returns an independent copy of the heap, with the same comparator and arity.
+// The complexity is O(n) where n is the size of the heap.
+func (h *Heap[T]) Clone() *Heap[T] {
 	return &Heap[T]{
-		cmp:  h.cmp,
-		data: slices.Clone(h.data),
-		idx:  maps.Clone(h.idx),
-		cnt:  maps.Clone(h.cnt),
-		size: h.size,
+		cmp:   h.cmp,
+		data:  slices.Clone(h.data),
+		arity: h.arity,
+		idx:   maps.Clone(h.idx),
+		cnt:   maps.Clone(h.cnt),
+		size:  h.size,
 	}
 }
@@ -10,11 +10,12 @@ import (
 )
 
 type Heap[T comparable] struct {
-	cmp  dsgo.Comparator[T]
-	data []T
-	idx  map[T]int
-	cnt  map[T]int
-	size int
+	cmp   dsgo.Comparator[T]
+	data  []T
+	arity int
+	idx   map[T]int
+	cnt   map[T]int
+	size  int
 }
 
 func New[T cmp.Ordered](ops ...Option[T]) *Heap[T] {
@@ -91,8 +92,9 @@ func (h *Heap[T]) Peek() (value T, ok bool) {
 	return h.data[0], true
 }
 
-// implements internal/heap.Interface
+// implements internal/heap.Interface and internal/heap.Arity
 
+func (h *Heap[T]) ArityX() int         { return h.arity }
 func (h *Heap[T]) LenX() int           { return len(h.data) }
 func (h *Heap[T]) LessX(i, j int) bool { return h.cmp(h.data[i], h.data[j]) < 0 }
 
@@ -195,3 +195,67 @@ func BenchmarkBinaryHeapPush100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkPush(b, heap, size)
 }
+
+func TestBinaryHeapCount(t *testing.T) {
+	h := New[string]()
+	h.Push("b", "a", "c", "a", "b", "a")
+	if actualValue := h.Count("a"); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue := h.Count("x"); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	distinct := h.Distinct()
+	slices.Sort(distinct)
+	if expectedValue := []string{"a", "b", "c"}; !slices.Equal(distinct, expectedValue) {
+		t.Errorf("Got %v expected %v", distinct, expectedValue)
+	}
+	if actualValue, expectedValue := h.SortedValues(), []string{"a", "a", "a", "b", "b", "c"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	h.Remove("a")
+	if actualValue := h.Count("a"); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+}
+
+func TestBinaryHeapClone(t *testing.T) {
+	h := New(WithArity[int](3))
+	h.Push(4, 2, 2, 7)
+	c := h.Clone()
+	c.Remove(2)
+	c.Remove(2)
+	c.Push(1, 9)
+	if actualValue, expectedValue := c.Values(), []int{1, 4, 7, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := h.Values(), []int{2, 2, 4, 7}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := h.Count(2); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := c.ArityX(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func TestBinaryHeapArity(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	heap := New(WithArity[int](4))
+	for i := 0; i < 1000; i++ {
+		heap.Push(r.Intn(300))
+	}
+	for i := 0; i < 100; i++ {
+		heap.Remove(r.Intn(300))
+	}
+	expected := heap.Values()
+	if !slices.IsSorted(expected) {
+		t.Errorf("unsorted values %v", expected)
+	}
+	for i := 0; heap.Len() > 0; i++ {
+		if actualValue, _ := heap.Pop(); actualValue != expected[i] {
+			t.Fatalf("Got %v expected %v", actualValue, expected[i])
+		}
+	}
+}
@@ -36,3 +36,13 @@ func WithData[T comparable, S ~[]T](data S) Option[T] {
 		copy(h.data, data)
 	}
 }
+
+// WithArity makes every node of the heap have d children instead of 2, d must be at least 2.
+func WithArity[T comparable](d int) Option[T] {
+	return func(h *Heap[T]) {
+		if d < 2 {
+			panic("heapx: arity must be at least 2")
+		}
+		h.arity = d
+	}
+}
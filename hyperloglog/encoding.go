@@ -0,0 +1,85 @@
+package hyperloglog
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+)
+
+// The binary form is a header followed by the registers:
+//
+//	version (1) | precision (1) | sparse (1) | registers
+//
+// Sparse registers are a count (4 bytes) followed by the sorted sparse entries, 4 bytes each,
+// an entry packing the index at precision 25 above the 6 bit value, integers in big endian.
+// Dense registers are 2^precision bytes.
+// Version 1 encoded a sparse entry in 5 bytes, it is no longer supported.
+const (
+	version    byte = 2
+	headerSize      = 3
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("hyperloglog: unsupported encoding version")
+	ErrInvalidData        = errors.New("hyperloglog: invalid encoded data")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	s.flush()
+	if !s.Sparse() {
+		buf := make([]byte, headerSize, headerSize+len(s.registers))
+		buf[0], buf[1] = version, s.p
+		return append(buf, s.registers...), nil
+	}
+	buf := make([]byte, headerSize+4+4*len(s.sparse))
+	buf[0], buf[1], buf[2] = version, s.p, 1
+	binary.BigEndian.PutUint32(buf[headerSize:], uint32(len(s.sparse)))
+	for k, e := range s.sparse {
+		binary.BigEndian.PutUint32(buf[headerSize+4+4*k:], e)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < headerSize {
+		return ErrInvalidData
+	}
+	if data[0] != version {
+		return ErrUnsupportedVersion
+	}
+	p, sparse, data := data[1], data[2], data[headerSize:]
+	if p < MinPrecision || p > MaxPrecision {
+		return ErrInvalidData
+	}
+	switch sparse {
+	case 0:
+		if len(data) != 1<<p || slices.ContainsFunc(data, func(r byte) bool { return r > 64-p+1 }) {
+			return ErrInvalidData
+		}
+		*s = Sketch{p: p, registers: slices.Clone(data)}
+	case 1:
+		if len(data) < 4 {
+			return ErrInvalidData
+		}
+		n := binary.BigEndian.Uint32(data)
+		if data = data[4:]; uint64(len(data)) != 4*uint64(n) || int(n) > 1<<p/4 {
+			return ErrInvalidData
+		}
+		entries := make([]uint32, n)
+		for k := range entries {
+			e := binary.BigEndian.Uint32(data[4*k:])
+			r := e & (1<<valueBits - 1)
+			if e>>valueBits >= 1<<sparsePrecision || r == 0 || r > 64-sparsePrecision+1 ||
+				k > 0 && e>>valueBits <= entries[k-1]>>valueBits {
+				return ErrInvalidData
+			}
+			entries[k] = e
+		}
+		*s = Sketch{p: p, sparse: entries}
+	default:
+		return ErrInvalidData
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+/*
+Package hyperloglog implements the HyperLogLog++ cardinality estimator.
+
+HyperLogLog estimates the number of distinct items in a stream with a few kilobytes.
+An item's 64-bit hash picks one of m = 2^p registers with its first p bits,
+and the register remembers the maximum position of the first 1 bit in the rest of the hash.
+Large positions are rare, so the registers reveal how many distinct hashes were seen,
+with a standard error of about 1.04 / sqrt(m).
+
+Following HyperLogLog++, hashes are 64 bits so there is no large range correction,
+and small cardinalities use a sparse representation: a sorted list of registers at precision 25,
+each packed with its value into 4 bytes, which is counted exactly-ish with linear counting
+and costs memory only for the registers touched. New registers collect in an unsorted buffer,
+merged into the list when it grows, and the list and buffer together never hold more than 2^p/4 entries:
+the sketch turns dense once they would outgrow the 2^p bytes of the dense registers.
+Dense sketches are estimated with Ertl's improved estimator instead of the empirical bias correction tables,
+it is unbiased over the full range of cardinalities.
+*/
+package hyperloglog
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/zrcoder/dsgo/internal/hashing"
+)
+
+const (
+	MinPrecision    = 4
+	MaxPrecision    = 18
+	sparsePrecision = 25
+	valueBits       = 6 // bits of a register value in a sparse entry, enough for 64-sparsePrecision+1
+)
+
+var ErrIncompatible = errors.New("hyperloglog: sketches have different precisions")
+
+// Sketch is a HyperLogLog++ sketch
+type Sketch struct {
+	p         uint8
+	sparse    []uint32 // entries at sparsePrecision sorted by index, one per index
+	pending   []uint32 // entries not merged into sparse yet, in any order
+	registers []uint8  // 2^p registers, nil while sparse
+}
+
+// New creates a sketch with 2^precision registers, precision is in [MinPrecision, MaxPrecision]
+func New(precision int) *Sketch {
+	if precision < MinPrecision || precision > MaxPrecision {
+		panic("hyperloglog: precision out of range")
+	}
+	return &Sketch{p: uint8(precision)}
+}
+
+// Precision returns the precision p, the sketch has 2^p registers
+func (s *Sketch) Precision() int { return int(s.p) }
+
+// Sparse returns true if the sketch still uses the sparse representation
+func (s *Sketch) Sparse() bool { return s.registers == nil }
+
+// Add adds data to the sketch
+func (s *Sketch) Add(data []byte) {
+	x := hashing.Sum64(data)
+	if s.Sparse() {
+		s.pending = append(s.pending, entry(split(x, sparsePrecision)))
+		if len(s.pending) > s.sparseLimit()/8 || len(s.sparse)+len(s.pending) > s.sparseLimit() {
+			s.flush()
+		}
+		return
+	}
+	i, r := split(x, s.p)
+	s.registers[i] = max(s.registers[i], r)
+}
+
+// Estimate returns the estimated number of distinct items added
+func (s *Sketch) Estimate() uint64 {
+	s.flush()
+	if s.Sparse() {
+		m := float64(uint64(1) << sparsePrecision)
+		return uint64(math.Round(m * math.Log(m/(m-float64(len(s.sparse))))))
+	}
+	return uint64(math.Round(ertl(s.registers, 64-int(s.p))))
+}
+
+// Merge merges other into s, so s estimates the distinct items added to either.
+// Both sketches must have the same precision.
+func (s *Sketch) Merge(other *Sketch) error {
+	if s.p != other.p {
+		return ErrIncompatible
+	}
+	if s.Sparse() && other.Sparse() {
+		s.pending = append(s.pending, other.sparse...)
+		s.pending = append(s.pending, other.pending...)
+		s.flush()
+		return nil
+	}
+	if s.Sparse() {
+		s.toDense()
+	}
+	if other.Sparse() {
+		s.addSparse(other.sparse)
+		s.addSparse(other.pending)
+		return nil
+	}
+	for i, r := range other.registers {
+		s.registers[i] = max(s.registers[i], r)
+	}
+	return nil
+}
+
+// Clone returns a copy of the sketch
+func (s *Sketch) Clone() *Sketch {
+	return &Sketch{
+		p:         s.p,
+		sparse:    slices.Clone(s.sparse),
+		pending:   slices.Clone(s.pending),
+		registers: slices.Clone(s.registers),
+	}
+}
+
+// Clear resets the sketch to the empty sparse state
+func (s *Sketch) Clear() {
+	s.sparse, s.pending, s.registers = nil, nil, nil
+}
+
+// sparseLimit is the number of 4 byte sparse entries beyond which the 2^p byte dense registers are smaller
+func (s *Sketch) sparseLimit() int {
+	return 1 << s.p / 4
+}
+
+// flush merges the pending entries into the sorted list, keeping the largest value of each index,
+// and turns the sketch dense if the list grows beyond sparseLimit
+func (s *Sketch) flush() {
+	if len(s.pending) == 0 {
+		return
+	}
+	slices.Sort(s.pending)
+	merged := make([]uint32, 0, len(s.sparse)+len(s.pending))
+	a, b := s.sparse, s.pending
+	for len(a) > 0 || len(b) > 0 {
+		var e uint32
+		if len(b) == 0 || len(a) > 0 && a[0] <= b[0] {
+			e, a = a[0], a[1:]
+		} else {
+			e, b = b[0], b[1:]
+		}
+		// entries sort by index then value, so a later entry of the same index has a larger value
+		if n := len(merged); n > 0 && merged[n-1]>>valueBits == e>>valueBits {
+			merged[n-1] = e
+		} else {
+			merged = append(merged, e)
+		}
+	}
+	s.sparse, s.pending = merged, s.pending[:0]
+	if len(s.sparse) > s.sparseLimit() {
+		s.toDense()
+	}
+}
+
+func (s *Sketch) toDense() {
+	s.registers = make([]uint8, 1<<s.p)
+	s.addSparse(s.sparse)
+	s.addSparse(s.pending)
+	s.sparse, s.pending = nil, nil
+}
+
+// addSparse folds sparse entries into the dense registers
+func (s *Sketch) addSparse(entries []uint32) {
+	for _, e := range entries {
+		j, r := denseFromSparse(e>>valueBits, uint8(e&(1<<valueBits-1)), s.p)
+		s.registers[j] = max(s.registers[j], r)
+	}
+}
+
+// entry packs a register at sparsePrecision and its value into a sparse entry
+func entry(i uint32, r uint8) uint32 {
+	return i<<valueBits | uint32(r)
+}
+
+// split returns the register index from the first p bits of x,
+// and the position of the first 1 bit in the remaining 64-p bits, which is 64-p+1 if they are all 0.
+func split(x uint64, p uint8) (uint32, uint8) {
+	return uint32(x >> (64 - p)), uint8(bits.LeadingZeros64(x<<p|1<<(p-1))) + 1
+}
+
+// denseFromSparse converts a register at sparsePrecision to the one it falls into at precision p
+func denseFromSparse(i uint32, r uint8, p uint8) (uint32, uint8) {
+	shift := sparsePrecision - p
+	j := i >> shift
+	if rest := i & (1<<shift - 1); rest != 0 {
+		return j, uint8(bits.LeadingZeros32(rest)) - (32 - shift) + 1
+	}
+	return j, shift + r
+}
+
+// ertl is the improved raw estimator from
+// Otmar Ertl, "New cardinality estimation algorithms for HyperLogLog sketches", 2017.
+// q is the number of hash bits after the index, so register values are in [0, q+1].
+func ertl(registers []uint8, q int) float64 {
+	counts := make([]int, q+2)
+	for _, r := range registers {
+		counts[r]++
+	}
+	m := float64(len(registers))
+	z := m * tau(1-float64(counts[q+1])/m)
+	for k := q; k >= 1; k-- {
+		z = 0.5 * (z + float64(counts[k]))
+	}
+	z += m * sigma(float64(counts[0])/m)
+	return m * m / (2 * math.Ln2) / z
+}
+
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if z == prev {
+			return z
+		}
+	}
+}
+
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == prev {
+			return z / 3
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package hyperloglog
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func key(i int) []byte {
+	return []byte("visitor-" + strconv.Itoa(i))
+}
+
+func relativeError(est uint64, n int) float64 {
+	return math.Abs(float64(est)-float64(n)) / float64(n)
+}
+
+func TestEstimate(t *testing.T) {
+	s := New(14)
+	if s.Estimate() != 0 {
+		t.Errorf("Got %v expected %v", s.Estimate(), 0)
+	}
+	n := 0
+	for _, target := range []int{10, 100, 1000, 10000, 100000, 1000000} {
+		for ; n < target; n++ {
+			s.Add(key(n))
+		}
+		if e := relativeError(s.Estimate(), n); e > 0.02 {
+			t.Errorf("estimate %d of %d is off by %.2f%%", s.Estimate(), n, e*100)
+		}
+	}
+	if s.Sparse() {
+		t.Error("expected a dense sketch")
+	}
+}
+
+func TestSparse(t *testing.T) {
+	s := New(14)
+	for i := 0; i < 1000; i++ {
+		s.Add(key(i))
+		s.Add(key(i))
+	}
+	if !s.Sparse() {
+		t.Error("expected a sparse sketch")
+	}
+	if est := s.Estimate(); est != 1000 {
+		t.Errorf("Got %v expected %v", est, 1000)
+	}
+	dense := s.Clone()
+	dense.toDense()
+	if e := relativeError(dense.Estimate(), 1000); e > 0.05 {
+		t.Errorf("dense estimate %d is off by %.2f%%", dense.Estimate(), e*100)
+	}
+	for i := 0; i <= 1<<14/4; i++ {
+		s.Add(key(i))
+	}
+	if s.Sparse() {
+		t.Error("expected a dense sketch")
+	}
+}
+
+func TestSparseSize(t *testing.T) {
+	s := New(14)
+	for i := 0; s.Sparse(); i++ {
+		if size := 4 * (len(s.sparse) + len(s.pending)); size > 1<<14 {
+			t.Fatalf("sparse sketch of %d bytes after %d items", size, i)
+		}
+		s.Add(key(i))
+		s.Add(key(i / 2))
+	}
+	s = New(14)
+	for i := 0; i < 3000; i++ {
+		s.Add(key(i % 2000))
+	}
+	s.flush()
+	for k := 1; k < len(s.sparse); k++ {
+		if s.sparse[k]>>valueBits <= s.sparse[k-1]>>valueBits {
+			t.Fatalf("sparse entries out of order at %d", k)
+		}
+	}
+	if est := s.Estimate(); est != 2000 {
+		t.Errorf("Got %v expected %v", est, 2000)
+	}
+}
+
+func TestDenseFromSparse(t *testing.T) {
+	for _, x := range []uint64{0, 1, math.MaxUint64, 1 << 63, 0x0000_0080_0000_0000, 0x1234_5678_9abc_def0} {
+		for p := uint8(MinPrecision); p <= MaxPrecision; p++ {
+			si, sr := split(x, sparsePrecision)
+			di, dr := split(x, p)
+			if i, r := denseFromSparse(si, sr, p); i != di || r != dr {
+				t.Errorf("x=%x p=%d: got (%d, %d) expected (%d, %d)", x, p, i, r, di, dr)
+			}
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	for _, sizes := range [][2]int{{100, 200}, {100, 50000}, {50000, 100}, {50000, 60000}} {
+		a, b, all := New(12), New(12), New(12)
+		for i := 0; i < sizes[0]; i++ {
+			a.Add(key(i))
+			all.Add(key(i))
+		}
+		for i := sizes[0] / 2; i < sizes[0]/2+sizes[1]; i++ {
+			b.Add(key(i))
+			all.Add(key(i))
+		}
+		if err := a.Merge(b); err != nil {
+			t.Fatal(err)
+		}
+		if a.Estimate() != all.Estimate() {
+			t.Errorf("sizes %v: merged estimate %d, expected %d", sizes, a.Estimate(), all.Estimate())
+		}
+	}
+	if err := New(12).Merge(New(14)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Got %v expected %v", err, ErrIncompatible)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	for _, n := range []int{0, 100, 100000} {
+		s := New(10)
+		for i := 0; i < n; i++ {
+			s.Add(key(i))
+		}
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := &Sketch{}
+		if err := d.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if d.Precision() != s.Precision() || d.Sparse() != s.Sparse() || d.Estimate() != s.Estimate() {
+			t.Errorf("n=%d: decoded sketch differs", n)
+		}
+		if err := d.UnmarshalBinary(data[:len(data)-1]); n > 0 && !errors.Is(err, ErrInvalidData) {
+			t.Errorf("Got %v expected %v", err, ErrInvalidData)
+		}
+		data[0] = 0
+		if err := d.UnmarshalBinary(data); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("Got %v expected %v", err, ErrUnsupportedVersion)
+		}
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	s := New(14)
+	keys := make([][]byte, 1<<16)
+	for i := range keys {
+		keys[i] = key(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Add(keys[i%len(keys)])
+	}
+}
@@ -0,0 +1,35 @@
+// Package hashing provides the stable hash functions shared by the probabilistic data structures.
+// The results never change between processes or releases, so hashed structures can be serialized.
+package hashing
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// Sum64 returns a 64-bit hash of data
+func Sum64(data []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(data)
+	return Mix64(h.Sum64())
+}
+
+// Sum128 returns two independent 64-bit hashes of data,
+// suitable for double hashing: h1 + i*h2 gives the i-th hash.
+func Sum128(data []byte) (h1, h2 uint64) {
+	h := fnv.New128a()
+	h.Write(data)
+	var sum [16]byte
+	h.Sum(sum[:0])
+	return Mix64(binary.BigEndian.Uint64(sum[:8])), Mix64(binary.BigEndian.Uint64(sum[8:]))
+}
+
+// Mix64 is the finalizer of murmur3, it spreads every input bit over the whole output.
+func Mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
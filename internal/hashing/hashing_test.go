@@ -0,0 +1,36 @@
+package hashing
+
+import (
+	"math/bits"
+	"strconv"
+	"testing"
+)
+
+func TestStable(t *testing.T) {
+	if Sum64([]byte("dsgo")) != Sum64([]byte("dsgo")) {
+		t.Error("hash is not stable")
+	}
+	h1, h2 := Sum128([]byte("dsgo"))
+	if h1 == h2 {
+		t.Error("expected different halves")
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	const n = 1 << 14
+	var ones [64]int
+	for i := 0; i < n; i++ {
+		x := Sum64([]byte(strconv.Itoa(i)))
+		for b := 0; b < 64; b++ {
+			ones[b] += int(x >> b & 1)
+		}
+	}
+	for b, c := range ones {
+		if c < n*45/100 || c > n*55/100 {
+			t.Errorf("bit %d set %d times of %d", b, c, n)
+		}
+	}
+	if bits.OnesCount64(Mix64(1)) < 16 {
+		t.Error("poor mixing")
+	}
+}
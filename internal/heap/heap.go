@@ -1,4 +1,5 @@
-// Package heap is a generics version of "container/heap" in the standard library
+// Package heap is a generics version of "container/heap" in the standard library,
+// generalized to d-ary heaps. Package heaps exposes it to other modules.
 package heap
 
 // The Interface type describes the requirements
@@ -7,7 +8,9 @@ package heap
 // min-heap with the following invariants (established after
 // [Init] has been called or if the data is empty or sorted):
 //
-//	!h.LessX(j, i) for 0 <= i < h.LenX() and 2*i+1 <= j <= 2*i+2 and j < h.LenX()
+//	!h.LessX(j, i) for 0 <= i < h.LenX() and d*i+1 <= j <= d*i+d and j < h.LenX()
+//
+// where d is the arity of the heap: 2 unless h implements [Arity].
 //
 // Note that [PushX] and [PopX] in this interface are for package heap's
 // implementation to call. To add and remove things from the heap,
@@ -20,15 +23,32 @@ type Interface[T any] interface {
 	PopX() T
 }
 
+// Arity is implemented by heaps whose nodes have ArityX() children instead of 2.
+// A d-ary heap is shallower, so Push compares fewer times and the nodes visited are closer in memory,
+// while Pop compares more children per level. A 4-ary heap is usually faster for push-heavy workloads.
+// The arity must not change while the heap is not empty.
+type Arity interface {
+	ArityX() int
+}
+
+func arity[T any](h Interface[T]) int {
+	if a, ok := h.(Arity); ok {
+		if d := a.ArityX(); d > 2 {
+			return d
+		}
+	}
+	return 2
+}
+
 // Init establishes the heap invariants required by the other routines in this package.
 // Init is idempotent with respect to the heap invariants
 // and may be called whenever the heap invariants may have been invalidated.
 // The complexity is O(n) where n = h.Len().
 func Init[T any](h Interface[T]) {
 	// heapify
-	n := h.LenX()
-	for i := n/2 - 1; i >= 0; i-- {
-		down(h, i, n)
+	n, d := h.LenX(), arity(h)
+	for i := (n - 2) / d; i >= 0; i-- {
+		down(h, i, n, d)
 	}
 }
 
@@ -36,7 +56,7 @@ func Init[T any](h Interface[T]) {
 // The complexity is O(log n) where n = h.Len().
 func Push[T any](h Interface[T], x T) {
 	h.PushX(x)
-	up(h, h.LenX()-1)
+	up(h, h.LenX()-1, arity(h))
 }
 
 // Pop removes and returns the minimum element (according to Less) from the heap.
@@ -45,7 +65,7 @@ func Push[T any](h Interface[T], x T) {
 func Pop[T any](h Interface[T]) T {
 	n := h.LenX() - 1
 	h.SwapX(0, n)
-	down(h, 0, n)
+	down(h, 0, n, arity(h))
 	return h.PopX()
 }
 
@@ -55,8 +75,8 @@ func RemoveIndex[T any](h Interface[T], i int) T {
 	n := h.LenX() - 1
 	if n != i {
 		h.SwapX(i, n)
-		if !down(h, i, n) {
-			up(h, i)
+		if d := arity(h); !down(h, i, n, d) {
+			up(h, i, d)
 		}
 	}
 	return h.PopX()
@@ -67,14 +87,14 @@ func RemoveIndex[T any](h Interface[T], i int) T {
 // but less expensive than, calling [Remove](h, i) followed by a Push of the new value.
 // The complexity is O(log n) where n = h.Len().
 func FixIndex[T any](h Interface[T], i int) {
-	if !down(h, i, h.LenX()) {
-		up(h, i)
+	if d := arity(h); !down(h, i, h.LenX(), d) {
+		up(h, i, d)
 	}
 }
 
-func up[T any](h Interface[T], j int) {
+func up[T any](h Interface[T], j, d int) {
 	for {
-		i := (j - 1) / 2 // parent
+		i := (j - 1) / d // parent
 		if i == j || !h.LessX(j, i) {
 			break
 		}
@@ -83,16 +103,18 @@ func up[T any](h Interface[T], j int) {
 	}
 }
 
-func down[T any](h Interface[T], i0, n int) bool {
+func down[T any](h Interface[T], i0, n, d int) bool {
 	i := i0
 	for {
-		j1 := 2*i + 1
+		j1 := d*i + 1
 		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
 			break
 		}
-		j := j1 // left child
-		if j2 := j1 + 1; j2 < n && h.LessX(j2, j1) {
-			j = j2 // = 2*i + 2  // right child
+		j := j1 // first child
+		for k := j1 + 1; k < j1+d && k < n; k++ {
+			if h.LessX(k, j) {
+				j = k // the least child
+			}
 		}
 		if !h.LessX(j, i) {
 			break
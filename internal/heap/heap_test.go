@@ -213,3 +213,51 @@ func TestFix(t *testing.T) {
 		h.verify(t, 0)
 	}
 }
+
+type DHeap[T cmp.Ordered] struct {
+	Heap[T]
+	d int
+}
+
+func (h *DHeap[T]) ArityX() int { return h.d }
+
+func (h *DHeap[T]) verify(t *testing.T) {
+	t.Helper()
+	for j := 1; j < h.LenX(); j++ {
+		if i := (j - 1) / h.d; h.LessX(j, i) {
+			t.Fatalf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.s[i], j, h.s[j])
+		}
+	}
+}
+
+func TestArity(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		h := &DHeap[int]{d: d}
+		for i := 0; i < 100; i++ {
+			h.PushX(rand.Intn(50))
+		}
+		Init(h)
+		h.verify(t)
+		for i := 0; i < 100; i++ {
+			Push(h, rand.Intn(50))
+			h.verify(t)
+		}
+		for i := 0; i < 20; i++ {
+			RemoveIndex(h, rand.Intn(h.LenX()))
+			h.verify(t)
+			elem := rand.Intn(h.LenX())
+			h.s[elem] = rand.Intn(50)
+			FixIndex(h, elem)
+			h.verify(t)
+		}
+		prev := -1
+		for h.LenX() > 0 {
+			x := Pop(h)
+			h.verify(t)
+			if x < prev {
+				t.Fatalf("arity %d: pop got %d after %d", d, x, prev)
+			}
+			prev = x
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package leftistheap
+
+import (
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+)
+
+var _ dsgo.Container[int] = (*Heap[int])(nil)
+
+// Len returns the size of the heap.
+// The complexity is O(1)
+func (h *Heap[T]) Len() int { return h.size }
+
+// Empty returns if the heap is empty.
+// The complexity is O(1)
+func (h *Heap[T]) Empty() bool { return h.Len() == 0 }
+
+// Values returns the sorted values in the heap
+func (h *Heap[T]) Values() []T {
+	res := make([]T, 0, h.size)
+	stack := []*node[T]{}
+	if h.root != nil {
+		stack = append(stack, h.root)
+	}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		res = append(res, n.value)
+		if n.left != nil {
+			stack = append(stack, n.left)
+		}
+		if n.right != nil {
+			stack = append(stack, n.right)
+		}
+	}
+	slices.SortStableFunc(res, h.cmp)
+	return res
+}
+
+// Clear clears and init the heap
+func (h *Heap[T]) Clear() {
+	h.root = nil
+	h.size = 0
+}
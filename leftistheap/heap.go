@@ -0,0 +1,105 @@
+/*
+Package leftistheap implements a leftist heap.
+
+A leftist heap is a heap-ordered binary tree where every left child has a rank at least that of its sibling,
+the rank being the length of the shortest path to a missing child.
+So the right spine has at most log(n+1) nodes, and merging two heaps along their right spines takes O(log n).
+Push and Pop are merges too. Unlike the amortized pairing heap, every operation is O(log n) in the worst case.
+*/
+package leftistheap
+
+import (
+	"cmp"
+
+	"github.com/zrcoder/dsgo"
+)
+
+type node[T any] struct {
+	value       T
+	left, right *node[T]
+	rank        int
+}
+
+func (n *node[T]) getRank() int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+type Heap[T any] struct {
+	cmp  dsgo.Comparator[T]
+	root *node[T]
+	size int
+}
+
+func New[T cmp.Ordered](ops ...Option[T]) *Heap[T] {
+	return NewWith[T](cmp.Compare[T], ops...)
+}
+
+func NewWith[T any](cmp dsgo.Comparator[T], ops ...Option[T]) *Heap[T] {
+	h := &Heap[T]{cmp: cmp}
+	for _, op := range ops {
+		op(h)
+	}
+	return h
+}
+
+// Push pushes the element value onto the heap.
+// The complexity is O(log n) where n is the size of the heap.
+func (h *Heap[T]) Push(value T) {
+	h.root = h.merge(h.root, &node[T]{value: value, rank: 1})
+	h.size++
+}
+
+// Pop removes and returns the peek element from the heap.
+// The complexity is O(log n) where n is the size of the heap.
+func (h *Heap[T]) Pop() (value T, ok bool) {
+	if h.root == nil {
+		return
+	}
+	value = h.root.value
+	h.root = h.merge(h.root.left, h.root.right)
+	h.size--
+	return value, true
+}
+
+// Peek returns the peek value of the heap
+// The complexity is O(1)
+func (h *Heap[T]) Peek() (value T, ok bool) {
+	if h.root == nil {
+		return
+	}
+	return h.root.value, true
+}
+
+// Meld moves all elements of other into the heap, leaving other empty.
+// Both heaps should order elements the same way, the result follows h's comparator.
+// The complexity is O(log n + log m) where n and m are the sizes of the heaps.
+func (h *Heap[T]) Meld(other *Heap[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.merge(h.root, other.root)
+	h.size += other.size
+	other.root, other.size = nil, 0
+}
+
+// merge merges the trees a and b along their right spines, and returns the new root
+func (h *Heap[T]) merge(a, b *node[T]) *node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+	a.right = h.merge(a.right, b)
+	if a.left.getRank() < a.right.getRank() {
+		a.left, a.right = a.right, a.left
+	}
+	a.rank = a.right.getRank() + 1
+	return a
+}
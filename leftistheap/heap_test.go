@@ -0,0 +1,202 @@
+package leftistheap
+
+import (
+	"cmp"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/zrcoder/dsgo"
+)
+
+func TestHeapPush(t *testing.T) {
+	heap := New[int]()
+
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	if actualValue, expectedValue := heap.Values(), []int{1, 2, 3}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := heap.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestHeapPop(t *testing.T) {
+	heap := NewWith(dsgo.Reverse(cmp.Compare[int]))
+
+	heap.Push(1)
+	heap.Push(3)
+	heap.Push(2)
+	heap.Pop()
+
+	if actualValue, ok := heap.Peek(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 0 || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 0 || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue := heap.Values(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+}
+
+func TestHeapWithData(t *testing.T) {
+	data := []int{5, 1, 9, 4, 8, 2, 7}
+	heap := New(WithData(data))
+	data[0] = 100
+	checkLeftist(t, heap)
+	if actualValue, expectedValue := heap.Values(), []int{1, 2, 4, 5, 7, 8, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	heap = NewWith(dsgo.Reverse(cmp.Compare[int]), WithData([]int{3, 1, 2}))
+	if actualValue, ok := heap.Peek(); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+// checkLeftist verifies the heap order, the leftist property and the ranks of every node,
+// and that the right spine of the root is no longer than log2(n+1)
+func checkLeftist(t *testing.T, h *Heap[int]) {
+	t.Helper()
+	size := 0
+	var walk func(n *node[int]) int
+	walk = func(n *node[int]) int {
+		if n == nil {
+			return 0
+		}
+		size++
+		for _, c := range []*node[int]{n.left, n.right} {
+			if c != nil && c.value < n.value {
+				t.Fatalf("child %v above parent %v", c.value, n.value)
+			}
+		}
+		left, right := walk(n.left), walk(n.right)
+		if left < right {
+			t.Fatalf("left rank %v below right rank %v at %v", left, right, n.value)
+		}
+		if n.rank != right+1 {
+			t.Fatalf("Got rank %v expected %v at %v", n.rank, right+1, n.value)
+		}
+		return n.rank
+	}
+	walk(h.root)
+	if size != h.Len() {
+		t.Fatalf("Got %v nodes expected %v", size, h.Len())
+	}
+	if limit := bits.Len(uint(size + 1)); h.root.getRank() >= limit {
+		t.Fatalf("right spine %v exceeds log2(%v)", h.root.getRank(), size+1)
+	}
+}
+
+func TestHeapRank(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	heap := New[int]()
+	for i := 0; i < 2000; i++ {
+		switch r.Intn(4) {
+		case 0:
+			heap.Pop()
+		case 1:
+			other := New[int]()
+			for j := r.Intn(50); j > 0; j-- {
+				other.Push(r.Intn(1000))
+			}
+			heap.Meld(other)
+		default:
+			heap.Push(r.Intn(1000))
+		}
+		checkLeftist(t, heap)
+	}
+	// ascending pushes build the worst case for a plain skew merge
+	heap.Clear()
+	for i := 0; i < 1000; i++ {
+		heap.Push(i)
+	}
+	checkLeftist(t, heap)
+}
+
+func TestHeapMeldStress(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	heaps := make([]*Heap[int], 512)
+	var expected []int
+	for i := range heaps {
+		data := make([]int, r.Intn(64))
+		for j := range data {
+			data[j] = r.Intn(10000)
+		}
+		heaps[i] = New(WithData(data))
+		expected = append(expected, data...)
+	}
+	// meld in a tournament so both sides grow large
+	for len(heaps) > 1 {
+		for i := 0; i+1 < len(heaps); i += 2 {
+			heaps[i].Meld(heaps[i+1])
+			if !heaps[i+1].Empty() {
+				t.Fatal("melded heap is not empty")
+			}
+			heaps[i/2] = heaps[i]
+		}
+		heaps = heaps[:len(heaps)/2]
+		checkLeftist(t, heaps[0])
+	}
+	heap := heaps[0]
+	heap.Meld(heap)
+	slices.Sort(expected)
+	var actual []int
+	for !heap.Empty() {
+		v, _ := heap.Pop()
+		actual = append(actual, v)
+	}
+	if !slices.Equal(actual, expected) {
+		t.Errorf("Got %v values expected %v, or a different order", len(actual), len(expected))
+	}
+}
+
+func BenchmarkHeapPush(b *testing.B) {
+	heap := New[int]()
+	for i := 0; i < b.N; i++ {
+		heap.Push(i)
+	}
+}
+
+func BenchmarkHeapPop(b *testing.B) {
+	b.StopTimer()
+	heap := New[int]()
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < b.N; i++ {
+		heap.Push(r.Int())
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		heap.Pop()
+	}
+}
+
+func BenchmarkHeapMeld(b *testing.B) {
+	heap := New[int]()
+	for i := 0; i < b.N; i++ {
+		other := New[int]()
+		other.Push(i)
+		other.Push(-i)
+		heap.Meld(other)
+	}
+}
@@ -0,0 +1,32 @@
+package leftistheap
+
+// Option configures a heap created by New or NewWith.
+// Nodes are allocated one by one, so unlike package heap there is no capacity to set.
+type Option[T any] func(h *Heap[T])
+
+// WithData fills the heap with a copy of data, merging the elements pairwise round by round in O(n).
+func WithData[T any, S ~[]T](data S) Option[T] {
+	return func(h *Heap[T]) {
+		if len(data) == 0 {
+			return
+		}
+		trees := make([]*node[T], len(data))
+		for i, v := range data {
+			trees[i] = &node[T]{value: v, rank: 1}
+		}
+		for len(trees) > 1 {
+			n := 0
+			for i := 0; i+1 < len(trees); i += 2 {
+				trees[n] = h.merge(trees[i], trees[i+1])
+				n++
+			}
+			if len(trees)%2 == 1 {
+				trees[n] = trees[len(trees)-1]
+				n++
+			}
+			trees = trees[:n]
+		}
+		h.root = h.merge(h.root, trees[0])
+		h.size += len(data)
+	}
+}
@@ -0,0 +1,30 @@
+package minmaxheap
+
+import (
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+)
+
+var _ dsgo.Container[int] = (*Heap[int])(nil)
+
+// Len returns the size of the heap.
+// The complexity is O(1)
+func (h *Heap[T]) Len() int { return len(h.data) }
+
+// Empty returns if the heap is empty.
+// The complexity is O(1)
+func (h *Heap[T]) Empty() bool { return h.Len() == 0 }
+
+// Values returns the values in the heap sorted from minimum to maximum
+func (h *Heap[T]) Values() []T {
+	res := slices.Clone(h.data)
+	slices.SortFunc(res, h.cmp)
+	return res
+}
+
+// Clear clears and init the heap
+func (h *Heap[T]) Clear() {
+	clear(h.data)
+	h.data = h.data[:0]
+}
@@ -0,0 +1,21 @@
+package minmaxheap
+
+import "fmt"
+
+type Job struct {
+	Name string
+	Cost int
+}
+
+func Example_jobs() {
+	h := NewWith(func(a, b Job) int { return a.Cost - b.Cost })
+	h.Push(Job{"resize", 3})
+	h.Push(Job{"backup", 9})
+	h.Push(Job{"ping", 1})
+	h.Push(Job{"reindex", 7})
+	cheapest, _ := h.PopMin()
+	priciest, _ := h.PopMax()
+	fmt.Println(cheapest.Name, priciest.Name)
+	// Output:
+	// ping backup
+}
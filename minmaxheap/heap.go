@@ -0,0 +1,188 @@
+/*
+Package minmaxheap implements a min-max heap, a double-ended priority queue.
+
+A min-max heap is a complete binary tree stored in a slice like a binary heap,
+whose levels alternate between min levels and max levels, starting with a min level at the root.
+Every node on a min level is no larger than its descendants, every node on a max level no smaller,
+so the minimum is the root and the maximum one of its children.
+Both ends can be popped in O(log n).
+*/
+package minmaxheap
+
+import (
+	"cmp"
+	"math/bits"
+
+	"github.com/zrcoder/dsgo"
+)
+
+type Heap[T any] struct {
+	cmp  dsgo.Comparator[T]
+	data []T
+}
+
+func New[T cmp.Ordered](ops ...Option[T]) *Heap[T] {
+	return NewWith[T](cmp.Compare[T], ops...)
+}
+
+func NewWith[T any](cmp dsgo.Comparator[T], ops ...Option[T]) *Heap[T] {
+	h := &Heap[T]{cmp: cmp}
+	for _, op := range ops {
+		op(h)
+	}
+	h.build()
+	return h
+}
+
+// build establishes the heap invariants.
+// The complexity is O(n) where n is the size of the heap.
+func (h *Heap[T]) build() {
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// Push pushes the element value onto the heap.
+// The complexity is O(log n) where n is the size of the heap.
+func (h *Heap[T]) Push(value T) {
+	h.data = append(h.data, value)
+	h.up(len(h.data) - 1)
+}
+
+// PeekMin returns the minimum value of the heap.
+// The complexity is O(1)
+func (h *Heap[T]) PeekMin() (value T, ok bool) {
+	if len(h.data) == 0 {
+		return
+	}
+	return h.data[0], true
+}
+
+// PeekMax returns the maximum value of the heap.
+// The complexity is O(1)
+func (h *Heap[T]) PeekMax() (value T, ok bool) {
+	if len(h.data) == 0 {
+		return
+	}
+	return h.data[h.maxIndex()], true
+}
+
+// PopMin removes and returns the minimum value of the heap.
+// The complexity is O(log n) where n is the size of the heap.
+func (h *Heap[T]) PopMin() (value T, ok bool) {
+	if len(h.data) == 0 {
+		return
+	}
+	return h.removeIndex(0), true
+}
+
+// PopMax removes and returns the maximum value of the heap.
+// The complexity is O(log n) where n is the size of the heap.
+func (h *Heap[T]) PopMax() (value T, ok bool) {
+	if len(h.data) == 0 {
+		return
+	}
+	return h.removeIndex(h.maxIndex()), true
+}
+
+func (h *Heap[T]) maxIndex() int {
+	switch len(h.data) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.cmp(h.data[2], h.data[1]) > 0 {
+		return 2
+	}
+	return 1
+}
+
+func (h *Heap[T]) removeIndex(i int) T {
+	n := len(h.data) - 1
+	value := h.data[i]
+	h.data[i] = h.data[n]
+	var zero T
+	h.data[n] = zero // avoid memory leak if T is pointer
+	h.data = h.data[:n]
+	if i < n {
+		h.down(i)
+	}
+	return value
+}
+
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// before reports whether data[i] should be above data[j] on a min level (min is true) or a max level
+func (h *Heap[T]) before(i, j int, min bool) bool {
+	c := h.cmp(h.data[i], h.data[j])
+	if min {
+		return c < 0
+	}
+	return c > 0
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+}
+
+func (h *Heap[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+	min := isMinLevel(i)
+	parent := (i - 1) / 2
+	// a node belonging to the other kind of level moves up among that kind
+	if h.before(parent, i, min) {
+		h.swap(i, parent)
+		h.upGrand(parent, !min)
+		return
+	}
+	h.upGrand(i, min)
+}
+
+// upGrand moves the node at i up through its grandparents, which are on the same kind of level
+func (h *Heap[T]) upGrand(i int, min bool) {
+	for i > 2 {
+		grand := ((i-1)/2 - 1) / 2
+		if !h.before(i, grand, min) {
+			return
+		}
+		h.swap(i, grand)
+		i = grand
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	min := isMinLevel(i)
+	n := len(h.data)
+	for {
+		first := 2*i + 1
+		if first >= n {
+			return
+		}
+		// m is the most extreme of children and grandchildren
+		m := first
+		for _, j := range [...]int{first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if j < n && h.before(j, m, min) {
+				m = j
+			}
+		}
+		if m <= first+1 {
+			if h.before(m, i, min) {
+				h.swap(m, i)
+			}
+			return
+		}
+		if !h.before(m, i, min) {
+			return
+		}
+		h.swap(m, i)
+		if parent := (m - 1) / 2; h.before(parent, m, min) {
+			h.swap(m, parent)
+		}
+		i = m
+	}
+}
@@ -0,0 +1,168 @@
+package minmaxheap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func (h *Heap[T]) verify(t *testing.T) {
+	t.Helper()
+	for i := range h.data {
+		min := isMinLevel(i)
+		// every descendant of i must not be before i
+		stack := []int{2*i + 1, 2*i + 2}
+		for len(stack) > 0 {
+			j := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if j >= len(h.data) {
+				continue
+			}
+			if h.before(j, i, min) {
+				t.Fatalf("heap invariant invalidated [%d] = %v, descendant [%d] = %v", i, h.data[i], j, h.data[j])
+			}
+			stack = append(stack, 2*j+1, 2*j+2)
+		}
+	}
+}
+
+func TestHeapPushPop(t *testing.T) {
+	heap := New[int]()
+	if _, ok := heap.PopMin(); ok {
+		t.Error("expected an empty heap")
+	}
+	if _, ok := heap.PeekMax(); ok {
+		t.Error("expected an empty heap")
+	}
+	for _, v := range []int{5, 3, 9, 1, 7} {
+		heap.Push(v)
+	}
+	heap.verify(t)
+	if actualValue, expectedValue := heap.Values(), []int{1, 3, 5, 7, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, ok := heap.PeekMin(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.PeekMax(); actualValue != 9 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+	for _, expected := range []int{9, 7} {
+		if actualValue, ok := heap.PopMax(); actualValue != expected || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expected)
+		}
+	}
+	for _, expected := range []int{1, 3} {
+		if actualValue, ok := heap.PopMin(); actualValue != expected || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expected)
+		}
+	}
+	if actualValue, ok := heap.PeekMax(); actualValue != 5 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue, ok := heap.PopMax(); actualValue != 5 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestHeapWithData(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = r.Intn(100)
+	}
+	heap := New(WithData(nums), WithCapacity[int](len(nums)+1))
+	heap.verify(t)
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+	for lo, hi := 0, len(sorted)-1; lo <= hi; lo, hi = lo+1, hi-1 {
+		if actualValue, _ := heap.PopMin(); actualValue != sorted[lo] {
+			t.Fatalf("Got %v expected %v", actualValue, sorted[lo])
+		}
+		if lo == hi {
+			break
+		}
+		if actualValue, _ := heap.PopMax(); actualValue != sorted[hi] {
+			t.Fatalf("Got %v expected %v", actualValue, sorted[hi])
+		}
+		heap.verify(t)
+	}
+	if !heap.Empty() {
+		t.Errorf("Got %v expected %v", heap.Len(), 0)
+	}
+}
+
+func TestHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	heap := NewWith(func(a, b float64) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+	var expected []float64
+	for i := 0; i < 5000; i++ {
+		switch r.Intn(4) {
+		case 0:
+			v, ok := heap.PopMin()
+			if ok != (len(expected) > 0) || ok && v != expected[0] {
+				t.Fatalf("Got %v expected %v", v, expected[0])
+			}
+			if ok {
+				expected = expected[1:]
+			}
+		case 1:
+			v, ok := heap.PopMax()
+			if ok != (len(expected) > 0) || ok && v != expected[len(expected)-1] {
+				t.Fatalf("Got %v expected %v", v, expected[len(expected)-1])
+			}
+			if ok {
+				expected = expected[:len(expected)-1]
+			}
+		default:
+			v := r.Float64()
+			heap.Push(v)
+			i, _ := slices.BinarySearch(expected, v)
+			expected = slices.Insert(expected, i, v)
+		}
+	}
+	heap.verify(t)
+	if actualValue := heap.Values(); !slices.Equal(actualValue, expected) {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+	heap.Clear()
+	if !heap.Empty() {
+		t.Error("expected an empty heap after Clear")
+	}
+}
+
+func BenchmarkHeapPush(b *testing.B) {
+	heap := New[int]()
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < b.N; i++ {
+		heap.Push(r.Int())
+	}
+}
+
+func BenchmarkHeapPopMinMax(b *testing.B) {
+	b.StopTimer()
+	heap := New[int]()
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < b.N; i++ {
+		heap.Push(r.Int())
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			heap.PopMin()
+		} else {
+			heap.PopMax()
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Package pairingheap implements a pairing heap.
+
+A pairing heap is a heap-ordered multiway tree, kept as a child-sibling binary tree.
+Push and Meld just link two trees, making the root with the larger value a child of the other, in O(1).
+Pop removes the root and pairs up its children in two passes, in O(log n) amortized.
+It is a good choice when heaps are merged often.
+*/
+package pairingheap
+
+import (
+	"cmp"
+
+	"github.com/zrcoder/dsgo"
+)
+
+type node[T any] struct {
+	value   T
+	child   *node[T] // first child
+	sibling *node[T] // next sibling
+}
+
+type Heap[T any] struct {
+	cmp  dsgo.Comparator[T]
+	root *node[T]
+	size int
+}
+
+func New[T cmp.Ordered](ops ...Option[T]) *Heap[T] {
+	return NewWith[T](cmp.Compare[T], ops...)
+}
+
+func NewWith[T any](cmp dsgo.Comparator[T], ops ...Option[T]) *Heap[T] {
+	h := &Heap[T]{cmp: cmp}
+	for _, op := range ops {
+		op(h)
+	}
+	return h
+}
+
+// Push pushes the element value onto the heap.
+// The complexity is O(1).
+func (h *Heap[T]) Push(value T) {
+	h.root = h.link(h.root, &node[T]{value: value})
+	h.size++
+}
+
+// Pop removes and returns the peek element from the heap.
+// The complexity is O(log n) amortized where n is the size of the heap.
+func (h *Heap[T]) Pop() (value T, ok bool) {
+	if h.root == nil {
+		return
+	}
+	value = h.root.value
+	h.root = h.mergePairs(h.root.child)
+	h.size--
+	return value, true
+}
+
+// Peek returns the peek value of the heap
+// The complexity is O(1)
+func (h *Heap[T]) Peek() (value T, ok bool) {
+	if h.root == nil {
+		return
+	}
+	return h.root.value, true
+}
+
+// Meld moves all elements of other into the heap, leaving other empty.
+// Both heaps should order elements the same way, the result follows h's comparator.
+// The complexity is O(1).
+func (h *Heap[T]) Meld(other *Heap[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.root, other.size = nil, 0
+}
+
+// link makes the root with the larger value the first child of the other, and returns the new root
+func (h *Heap[T]) link(a, b *node[T]) *node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// mergePairs links the siblings from first in pairs left to right,
+// then links the resulting trees right to left into one.
+func (h *Heap[T]) mergePairs(first *node[T]) *node[T] {
+	var pairs *node[T] // linked pairs, in reverse order through sibling
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			a.sibling = pairs
+			pairs = a
+			break
+		}
+		first = b.sibling
+		a.sibling, b.sibling = nil, nil
+		p := h.link(a, b)
+		p.sibling = pairs
+		pairs = p
+	}
+	var root *node[T]
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = h.link(pairs, root)
+		pairs = next
+	}
+	return root
+}
@@ -0,0 +1,208 @@
+package pairingheap
+
+import (
+	"cmp"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/zrcoder/dsgo"
+)
+
+func TestHeapPush(t *testing.T) {
+	heap := New[int]()
+
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	if actualValue, expectedValue := heap.Values(), []int{1, 2, 3}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := heap.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestHeapPop(t *testing.T) {
+	heap := NewWith(dsgo.Reverse(cmp.Compare[int]))
+
+	heap.Push(1)
+	heap.Push(3)
+	heap.Push(2)
+	heap.Pop()
+
+	if actualValue, ok := heap.Peek(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.Pop(); actualValue != 0 || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 0 || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue := heap.Values(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+}
+
+func TestHeapWithData(t *testing.T) {
+	data := []int{5, 1, 9, 4, 8, 2, 7}
+	heap := New(WithData(data))
+	data[0] = 100
+	if actualValue, expectedValue := heap.Values(), []int{1, 2, 4, 5, 7, 8, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	heap = NewWith(dsgo.Reverse(cmp.Compare[int]), WithData([]int{3, 1, 2}))
+	if actualValue, ok := heap.Peek(); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func children(n *node[int]) []int {
+	var res []int
+	for c := n.child; c != nil; c = c.sibling {
+		res = append(res, c.value)
+	}
+	return res
+}
+
+func TestHeapTwoPass(t *testing.T) {
+	heap := New[int]()
+	for i := 0; i <= 8; i++ {
+		heap.Push(i)
+	}
+	// every push lost to the root, so its children are the other values, latest first
+	if actualValue, expectedValue := children(heap.root), []int{8, 7, 6, 5, 4, 3, 2, 1}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	heap.Pop()
+	// the first pass links (8 7) (6 5) (4 3) (2 1), the second links the winners from the last pair back,
+	// so the new root keeps half of the old children plus its own pair
+	if actualValue, expectedValue := children(heap.root), []int{7, 5, 3, 2}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for n := heap.root.child; n.sibling != nil; n = n.sibling {
+		if actualValue, expectedValue := children(n), []int{n.value + 1}; !slices.Equal(actualValue, expectedValue) {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+// countingHeap returns a heap counting its comparisons in *count
+func countingHeap(count *int) *Heap[int] {
+	return NewWith(func(a, b int) int {
+		*count++
+		return cmp.Compare(a, b)
+	})
+}
+
+func TestHeapComparisons(t *testing.T) {
+	const n = 1 << 14
+	r := rand.New(rand.NewSource(3))
+	inputs := map[string]func(i int) int{
+		"ascending":  func(i int) int { return i },
+		"descending": func(i int) int { return n - i },
+		"random":     func(int) int { return r.Int() },
+	}
+	for name, input := range inputs {
+		count := 0
+		heap := countingHeap(&count)
+		for i := 0; i < n; i++ {
+			heap.Push(input(i))
+		}
+		if count > n {
+			t.Errorf("%s: Got %v comparisons for %v pushes", name, count, n)
+		}
+		prev, _ := heap.Pop()
+		for !heap.Empty() {
+			curr, _ := heap.Pop()
+			if prev > curr {
+				t.Fatalf("%s: Heap property invalidated. prev: %v current: %v", name, prev, curr)
+			}
+			prev = curr
+		}
+		// two-pass pairing keeps popping everything within O(n log n)
+		if limit := 3 * n * bits.Len(n); count > limit {
+			t.Errorf("%s: Got %v comparisons expected at most %v", name, count, limit)
+		}
+	}
+}
+
+func TestHeapMeldStress(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	count := 0
+	heap := countingHeap(&count)
+	var expected []int
+	for i := 0; i < 10000; i++ {
+		other := New(WithData([]int{r.Intn(100000), r.Intn(100000)}))
+		expected = append(expected, other.Values()...)
+		before := count
+		heap.Meld(other)
+		// a meld is a single link
+		if count-before > 1 {
+			t.Fatalf("Got %v comparisons for a meld expected 1", count-before)
+		}
+		if i%100 == 0 {
+			v, _ := heap.Pop()
+			at := slices.Index(expected, v)
+			expected = slices.Delete(expected, at, at+1)
+		}
+	}
+	slices.Sort(expected)
+	if actualValue := heap.Len(); actualValue != len(expected) {
+		t.Fatalf("Got %v expected %v", actualValue, len(expected))
+	}
+	var actual []int
+	for !heap.Empty() {
+		v, _ := heap.Pop()
+		actual = append(actual, v)
+	}
+	if !slices.Equal(actual, expected) {
+		t.Error("popped values differ from the pushed ones")
+	}
+}
+
+func BenchmarkHeapPush(b *testing.B) {
+	heap := New[int]()
+	for i := 0; i < b.N; i++ {
+		heap.Push(i)
+	}
+}
+
+func BenchmarkHeapPop(b *testing.B) {
+	b.StopTimer()
+	heap := New[int]()
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < b.N; i++ {
+		heap.Push(r.Int())
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		heap.Pop()
+	}
+}
+
+func BenchmarkHeapMeld(b *testing.B) {
+	heap := New[int]()
+	for i := 0; i < b.N; i++ {
+		other := New[int]()
+		other.Push(i)
+		other.Push(-i)
+		heap.Meld(other)
+	}
+}
@@ -0,0 +1,15 @@
+package pairingheap
+
+// Option configures a heap created by New or NewWith.
+// Nodes are allocated one by one, so unlike package heap there is no capacity to set.
+type Option[T any] func(h *Heap[T])
+
+// WithData fills the heap with a copy of data in O(n), each element linked as a Push would.
+// The first Pop then pays for pairing up the children of the root.
+func WithData[T any, S ~[]T](data S) Option[T] {
+	return func(h *Heap[T]) {
+		for _, v := range data {
+			h.Push(v)
+		}
+	}
+}
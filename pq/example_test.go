@@ -0,0 +1,26 @@
+package pq
+
+import "fmt"
+
+func Example_dijkstra() {
+	graph := map[string]map[string]int{
+		"s": {"a": 7, "b": 2},
+		"b": {"a": 3, "t": 8},
+		"a": {"t": 1},
+	}
+	dist := map[string]int{"s": 0}
+	q := New[string, int]()
+	q.Push("s", 0)
+	for !q.Empty() {
+		u, d, _ := q.Pop()
+		for v, w := range graph[u] {
+			if old, ok := dist[v]; !ok || d+w < old {
+				dist[v] = d + w
+				q.Push(v, d+w)
+			}
+		}
+	}
+	fmt.Println(dist["t"])
+	// Output:
+	// 6
+}
@@ -0,0 +1,143 @@
+// Package pq implements an indexed priority queue.
+//
+// Unlike heapx.Heap, which indexes elements by their own value,
+// PQ stores a priority beside each key, so the priority of a key can change in O(log n)
+// without touching the key, as needed by Dijkstra and A*.
+package pq
+
+import (
+	"cmp"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/internal/heap"
+)
+
+type item[K comparable, P any] struct {
+	key      K
+	priority P
+}
+
+// PQ is a priority queue of unique keys, the key with the least priority comes first
+type PQ[K comparable, P any] struct {
+	cmp   dsgo.Comparator[P]
+	items []item[K, P]
+	idx   map[K]int // index of each key in items
+}
+
+func New[K comparable, P cmp.Ordered]() *PQ[K, P] {
+	return NewWith[K](cmp.Compare[P])
+}
+
+func NewWith[K comparable, P any](cmp dsgo.Comparator[P]) *PQ[K, P] {
+	return &PQ[K, P]{cmp: cmp, idx: make(map[K]int)}
+}
+
+// Push adds key with the given priority, or updates its priority if key is already present.
+// The complexity is O(log n) where n is the size of the queue.
+func (q *PQ[K, P]) Push(key K, priority P) {
+	if q.Update(key, priority) {
+		return
+	}
+	q.idx[key] = len(q.items)
+	heap.Push(q, item[K, P]{key: key, priority: priority})
+}
+
+// Update changes the priority of key, it returns false if key is not present.
+// The complexity is O(log n) where n is the size of the queue.
+func (q *PQ[K, P]) Update(key K, priority P) bool {
+	i, ok := q.idx[key]
+	if !ok {
+		return false
+	}
+	q.items[i].priority = priority
+	heap.FixIndex(q, i)
+	return true
+}
+
+// Pop removes and returns the key with the least priority, and its priority.
+// The complexity is O(log n) where n is the size of the queue.
+func (q *PQ[K, P]) Pop() (key K, priority P, ok bool) {
+	if len(q.items) == 0 {
+		return
+	}
+	it := heap.Pop[item[K, P]](q)
+	delete(q.idx, it.key)
+	return it.key, it.priority, true
+}
+
+// Peek returns the key with the least priority, and its priority.
+// The complexity is O(1).
+func (q *PQ[K, P]) Peek() (key K, priority P, ok bool) {
+	if len(q.items) == 0 {
+		return
+	}
+	return q.items[0].key, q.items[0].priority, true
+}
+
+// Remove removes key, it returns false if key is not present.
+// The complexity is O(log n) where n is the size of the queue.
+func (q *PQ[K, P]) Remove(key K) bool {
+	i, ok := q.idx[key]
+	if !ok {
+		return false
+	}
+	heap.RemoveIndex[item[K, P]](q, i)
+	delete(q.idx, key)
+	return true
+}
+
+// Priority returns the priority of key, ok is false if key is not present.
+// The complexity is O(1).
+func (q *PQ[K, P]) Priority(key K) (priority P, ok bool) {
+	i, ok := q.idx[key]
+	if !ok {
+		return
+	}
+	return q.items[i].priority, true
+}
+
+// Contains returns true if key is present.
+// The complexity is O(1).
+func (q *PQ[K, P]) Contains(key K) bool {
+	_, ok := q.idx[key]
+	return ok
+}
+
+// Len returns the number of keys.
+// The complexity is O(1)
+func (q *PQ[K, P]) Len() int { return len(q.items) }
+
+// Empty returns if the queue is empty.
+// The complexity is O(1)
+func (q *PQ[K, P]) Empty() bool { return q.Len() == 0 }
+
+// Clear removes all keys
+func (q *PQ[K, P]) Clear() {
+	clear(q.items)
+	q.items = q.items[:0]
+	clear(q.idx)
+}
+
+// implements internal/heap.Interface
+
+func (q *PQ[K, P]) LenX() int { return len(q.items) }
+
+func (q *PQ[K, P]) LessX(i, j int) bool {
+	return q.cmp(q.items[i].priority, q.items[j].priority) < 0
+}
+
+func (q *PQ[K, P]) SwapX(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.idx[q.items[i].key] = i
+	q.idx[q.items[j].key] = j
+}
+
+func (q *PQ[K, P]) PushX(x item[K, P]) { q.items = append(q.items, x) }
+
+func (q *PQ[K, P]) PopX() item[K, P] {
+	n := len(q.items)
+	x := q.items[n-1]
+	q.items[n-1] = item[K, P]{} // avoid memory leak if K or P is pointer
+	q.items = q.items[:n-1]
+	return x
+}
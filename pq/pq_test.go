@@ -0,0 +1,98 @@
+package pq
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPQ(t *testing.T) {
+	q := New[string, int]()
+	if _, _, ok := q.Pop(); ok || !q.Empty() {
+		t.Error("expected an empty queue")
+	}
+	q.Push("a", 5)
+	q.Push("b", 3)
+	q.Push("c", 8)
+	q.Push("a", 1)
+	if actualValue := q.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if key, priority, ok := q.Peek(); key != "a" || priority != 1 || !ok {
+		t.Errorf("Got %v:%v expected %v:%v", key, priority, "a", 1)
+	}
+	if !q.Update("c", 0) || q.Update("x", 0) {
+		t.Error("unexpected Update result")
+	}
+	if priority, ok := q.Priority("c"); priority != 0 || !ok {
+		t.Errorf("Got %v expected %v", priority, 0)
+	}
+	if _, ok := q.Priority("x"); ok {
+		t.Error("expected x to be missing")
+	}
+	if !q.Remove("a") || q.Remove("a") || q.Contains("a") || !q.Contains("b") {
+		t.Error("unexpected Remove result")
+	}
+	for _, expected := range []string{"c", "b"} {
+		if key, _, ok := q.Pop(); key != expected || !ok {
+			t.Errorf("Got %v expected %v", key, expected)
+		}
+	}
+	if !q.Empty() || q.Contains("b") {
+		t.Error("expected an empty queue")
+	}
+	q.Push("x", 1)
+	q.Clear()
+	if !q.Empty() || q.Contains("x") {
+		t.Error("expected an empty queue after Clear")
+	}
+}
+
+func TestPQRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	q := NewWith[int](func(a, b float64) int {
+		switch {
+		case a < b:
+			return 1
+		case a > b:
+			return -1
+		}
+		return 0
+	})
+	expected := map[int]float64{}
+	for i := 0; i < 10000; i++ {
+		key := r.Intn(1000)
+		switch r.Intn(3) {
+		case 0, 1:
+			p := r.Float64()
+			q.Push(key, p)
+			expected[key] = p
+		case 2:
+			if _, had := expected[key]; q.Remove(key) != had {
+				t.Fatalf("unexpected Remove result for %d", key)
+			}
+			delete(expected, key)
+		}
+	}
+	if q.Len() != len(expected) {
+		t.Errorf("Got %v expected %v", q.Len(), len(expected))
+	}
+	prev := 2.0
+	for !q.Empty() {
+		key, p, _ := q.Pop()
+		if p > prev || p != expected[key] {
+			t.Fatalf("unexpected pop %v:%v after %v", key, p, prev)
+		}
+		prev = p
+	}
+}
+
+func BenchmarkUpdate(b *testing.B) {
+	q := New[int, int]()
+	for i := 0; i < 10000; i++ {
+		q.Push(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Update(i%10000, -i)
+	}
+}
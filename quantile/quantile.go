@@ -0,0 +1,111 @@
+// Package quantile tracks a running quantile, such as the median, of a stream.
+//
+// A Tracker splits the items into two heaps: a max heap of the smallest items up to the quantile's rank,
+// and a min heap of the rest. The quantile is the top of the first heap.
+// Both heaps are heapx.Heap, so an item can be removed by value, which lets a Tracker serve a sliding window.
+package quantile
+
+import (
+	"cmp"
+	"math"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/heapx"
+)
+
+type Tracker[T comparable] struct {
+	q    float64
+	cmp  dsgo.Comparator[T]
+	low  *heapx.Heap[T] // max heap of the rank(n) smallest items
+	high *heapx.Heap[T] // min heap of the other items
+}
+
+// New creates a tracker of the q-quantile, q in [0, 1]
+func New[T cmp.Ordered](q float64) *Tracker[T] {
+	return NewWith(q, cmp.Compare[T])
+}
+
+// NewMedian creates a tracker of the median
+func NewMedian[T cmp.Ordered]() *Tracker[T] {
+	return New[T](0.5)
+}
+
+// NewWith creates a tracker of the q-quantile of items ordered by cmp, q in [0, 1]
+func NewWith[T comparable](q float64, cmp dsgo.Comparator[T]) *Tracker[T] {
+	if q < 0 || q > 1 {
+		panic("quantile: q must be in [0, 1]")
+	}
+	return &Tracker[T]{
+		q:    q,
+		cmp:  cmp,
+		low:  heapx.NewWith(dsgo.Reverse(cmp)),
+		high: heapx.NewWith(cmp),
+	}
+}
+
+// Add adds value.
+// The complexity is O(log n) where n is the number of items.
+func (t *Tracker[T]) Add(value T) {
+	if top, ok := t.low.Peek(); ok && t.cmp(value, top) <= 0 {
+		t.low.Push(value)
+	} else {
+		t.high.Push(value)
+	}
+	t.balance()
+}
+
+// Remove removes one occurrence of value, it returns false if value is not present.
+// The complexity is O(log n) where n is the number of items.
+func (t *Tracker[T]) Remove(value T) bool {
+	removed := false
+	if top, ok := t.low.Peek(); ok && t.cmp(value, top) <= 0 {
+		removed = t.low.Remove(value)
+	}
+	if !removed {
+		removed = t.high.Remove(value)
+	}
+	if removed {
+		t.balance()
+	}
+	return removed
+}
+
+// Value returns the q-quantile by the nearest-rank method: the smallest item
+// no smaller than a fraction q of the items, which is the lower median for q = 0.5.
+// ok is false if there are no items.
+// The complexity is O(1).
+func (t *Tracker[T]) Value() (value T, ok bool) {
+	return t.low.Peek()
+}
+
+// Quantile returns q
+func (t *Tracker[T]) Quantile() float64 { return t.q }
+
+// Len returns the number of items
+func (t *Tracker[T]) Len() int { return t.low.Len() + t.high.Len() }
+
+// Clear removes all items
+func (t *Tracker[T]) Clear() {
+	t.low.Clear()
+	t.high.Clear()
+}
+
+// rank returns the 1-based rank of the quantile among n items, 0 if n is 0
+func (t *Tracker[T]) rank(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return max(1, int(math.Ceil(t.q*float64(n))))
+}
+
+func (t *Tracker[T]) balance() {
+	r := t.rank(t.Len())
+	for t.low.Len() > r {
+		v, _ := t.low.Pop()
+		t.high.Push(v)
+	}
+	for t.low.Len() < r {
+		v, _ := t.high.Pop()
+		t.low.Push(v)
+	}
+}
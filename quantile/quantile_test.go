@@ -0,0 +1,102 @@
+package quantile
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func nearestRank(sorted []int, q float64) int {
+	r := max(1, int(math.Ceil(q*float64(len(sorted)))))
+	return sorted[r-1]
+}
+
+func TestMedian(t *testing.T) {
+	m := NewMedian[int]()
+	if _, ok := m.Value(); ok {
+		t.Error("expected no median")
+	}
+	for i, expected := range []int{5, 5, 5, 5, 7, 5, 5} {
+		m.Add([]int{5, 9, 1, 7, 8, 2, 3}[i])
+		if actualValue, ok := m.Value(); actualValue != expected || !ok {
+			t.Errorf("Got %v expected %v after %d items", actualValue, expected, i+1)
+		}
+	}
+	if !m.Remove(5) || m.Remove(100) {
+		t.Error("unexpected Remove result")
+	}
+	if actualValue, _ := m.Value(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if m.Len() != 6 || m.Quantile() != 0.5 {
+		t.Errorf("Got %v expected %v", m.Len(), 6)
+	}
+	m.Clear()
+	if _, ok := m.Value(); ok || m.Len() != 0 {
+		t.Error("expected no items after Clear")
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	const window = 50
+	for _, q := range []float64{0, 0.1, 0.5, 0.9, 0.99, 1} {
+		tracker := New[int](q)
+		var items []int
+		for i := 0; i < 2000; i++ {
+			v := r.Intn(100)
+			items = append(items, v)
+			tracker.Add(v)
+			if len(items) > window {
+				if !tracker.Remove(items[0]) {
+					t.Fatalf("failed to remove %v", items[0])
+				}
+				items = items[1:]
+			}
+			expected := nearestRank(slices.Sorted(slices.Values(items)), q)
+			if actualValue, _ := tracker.Value(); actualValue != expected {
+				t.Fatalf("q=%v: Got %v expected %v", q, actualValue, expected)
+			}
+		}
+	}
+}
+
+func TestNewWith(t *testing.T) {
+	type sample struct {
+		id      int
+		latency float64
+	}
+	tracker := NewWith(0.9, func(a, b sample) int {
+		switch {
+		case a.latency < b.latency:
+			return -1
+		case a.latency > b.latency:
+			return 1
+		}
+		return a.id - b.id
+	})
+	for i := 1; i <= 100; i++ {
+		tracker.Add(sample{id: i, latency: float64(i) / 10})
+	}
+	if actualValue, _ := tracker.Value(); actualValue.latency != 9 {
+		t.Errorf("Got %v expected %v", actualValue.latency, 9)
+	}
+}
+
+func BenchmarkAddRemove(b *testing.B) {
+	r := rand.New(rand.NewSource(3))
+	tracker := NewMedian[int]()
+	items := make([]int, 0, 1000)
+	for i := 0; i < b.N; i++ {
+		v := r.Intn(1 << 20)
+		tracker.Add(v)
+		items = append(items, v)
+		if len(items) == cap(items) {
+			for _, v := range items[:500] {
+				tracker.Remove(v)
+			}
+			items = append(items[:0], items[500:]...)
+		}
+	}
+}
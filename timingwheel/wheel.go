@@ -0,0 +1,254 @@
+// Package timingwheel implements a hierarchical timing wheel, for scheduling large numbers of timers.
+//
+// Level 0 of the wheel has one slot per tick, and each slot of level l spans size^l ticks.
+// A timer is put in the lowest level whose slots reach its expiry, which takes O(1),
+// and cancelling it takes O(1) too. When a slot of a higher level comes round,
+// its timers cascade down to lower levels, until they expire from level 0.
+//
+// The wheel does not run on its own: Tick advances it to a given time and returns what expired,
+// so it is deterministic and easy to test. A Wheel is not safe for concurrent use.
+package timingwheel
+
+import (
+	"math"
+	"time"
+
+	"github.com/zrcoder/dsgo/list"
+	"github.com/zrcoder/dsgo/ringbuffer"
+)
+
+// Timer is a handle to a value added to a Wheel
+type Timer[T any] struct {
+	value  T
+	expiry int64 // the tick at which the timer expires
+	slot   *list.List[*Timer[T]]
+	elem   *list.Element[*Timer[T]]
+	w      *Wheel[T]
+}
+
+func (t *Timer[T]) Value() T { return t.value }
+
+// Wheel is a hierarchical timing wheel
+type Wheel[T any] struct {
+	tick    time.Duration
+	size    int
+	origin  time.Time
+	current int64 // ticks elapsed since origin
+	len     int
+	// levels[l] holds the slots of level l, index 0 is the slot of the current time,
+	// the buffer is rotated by one each time the level advances a slot.
+	levels []*ringbuffer.Buffer[*list.List[*Timer[T]]]
+	spans  []int64 // spans[l] is size^l, the ticks spanned by a slot of level l
+}
+
+type Option[T any] func(w *Wheel[T])
+
+// WithTick sets the duration of one tick, the resolution of the wheel, it defaults to a millisecond
+func WithTick[T any](d time.Duration) Option[T] {
+	return func(w *Wheel[T]) {
+		if d <= 0 {
+			panic("timingwheel: tick must be positive")
+		}
+		w.tick = d
+	}
+}
+
+// WithWheelSize sets the number of slots in each level, it defaults to 64
+func WithWheelSize[T any](n int) Option[T] {
+	return func(w *Wheel[T]) {
+		if n < 2 {
+			panic("timingwheel: wheel size must be at least 2")
+		}
+		w.size = n
+	}
+}
+
+// WithLevels sets the number of levels, it defaults to 4.
+// Timers beyond the reach of the top level wait in its last slot and are placed again when it comes round.
+func WithLevels[T any](n int) Option[T] {
+	return func(w *Wheel[T]) {
+		if n < 1 {
+			panic("timingwheel: levels must be at least 1")
+		}
+		w.levels = make([]*ringbuffer.Buffer[*list.List[*Timer[T]]], n)
+	}
+}
+
+// New creates a wheel whose time starts at start
+func New[T any](start time.Time, ops ...Option[T]) *Wheel[T] {
+	w := &Wheel[T]{
+		tick:   time.Millisecond,
+		size:   64,
+		origin: start,
+		levels: make([]*ringbuffer.Buffer[*list.List[*Timer[T]]], 4),
+	}
+	for _, op := range ops {
+		op(w)
+	}
+	w.spans = make([]int64, len(w.levels))
+	span := int64(1)
+	for l := range w.levels {
+		w.spans[l] = span
+		span *= int64(w.size)
+		w.levels[l] = ringbuffer.New[*list.List[*Timer[T]]](w.size)
+		for range w.size {
+			w.levels[l].Enqueue(list.New[*Timer[T]]())
+		}
+	}
+	return w
+}
+
+// Now returns the time the wheel has advanced to, truncated to a tick
+func (w *Wheel[T]) Now() time.Time {
+	return w.origin.Add(time.Duration(w.current) * w.tick)
+}
+
+// Add schedules value to expire at deadline, rounded up to a tick.
+// A deadline not after Now expires on the next tick.
+func (w *Wheel[T]) Add(value T, deadline time.Time) *Timer[T] {
+	d := deadline.Sub(w.origin)
+	expiry := int64(d / w.tick)
+	if d%w.tick > 0 {
+		expiry++
+	}
+	t := &Timer[T]{value: value, expiry: max(expiry, w.current+1), w: w}
+	w.place(t)
+	w.len++
+	return t
+}
+
+// AddAfter schedules value to expire after delay from Now
+func (w *Wheel[T]) AddAfter(value T, delay time.Duration) *Timer[T] {
+	return w.Add(value, w.Now().Add(delay))
+}
+
+// Cancel removes the timer from the wheel,
+// it returns false if the timer belongs to another wheel, or already expired or was cancelled
+func (w *Wheel[T]) Cancel(t *Timer[T]) bool {
+	if t.w != w || t.slot == nil {
+		return false
+	}
+	t.slot.Remove(t.elem)
+	t.slot, t.elem = nil, nil
+	w.len--
+	return true
+}
+
+// Tick advances the wheel to now and returns the values of the expired timers in order of expiry.
+// It jumps over the ticks where no slot with timers comes round,
+// so its cost depends on the number of such slots, not on the time elapsed.
+func (w *Wheel[T]) Tick(now time.Time) []T {
+	target := int64(now.Sub(w.origin) / w.tick)
+	var expired []T
+	for w.current < target {
+		next := w.next()
+		if next > target {
+			w.advance(target)
+			break
+		}
+		w.advance(next - 1)
+		expired = w.step(expired)
+	}
+	return expired
+}
+
+// next returns the earliest tick at which a slot with timers comes round, or math.MaxInt64 if there is none
+func (w *Wheel[T]) next() int64 {
+	next := int64(math.MaxInt64)
+	for l, span := range w.spans {
+		base := w.current / span
+		if (base+1)*span >= next {
+			// the slots of this and higher levels come round later
+			break
+		}
+		for i := 1; i < w.size; i++ {
+			if slot, _ := w.levels[l].Get(i); slot.Len() > 0 {
+				next = min(next, (base+int64(i))*span)
+				break
+			}
+		}
+	}
+	return next
+}
+
+// advance moves the wheel to tick to, no slot with timers may come round on the way
+func (w *Wheel[T]) advance(to int64) {
+	for l, span := range w.spans {
+		// a level with timers is passed by fewer slots than its size, an empty one may turn any number of times
+		n := (to/span - w.current/span) % int64(w.size)
+		for range n {
+			w.rotate(l)
+		}
+	}
+	w.current = to
+}
+
+// step advances the wheel by one tick, cascading the higher level slots that come round,
+// and collects the values of the timers that expire
+func (w *Wheel[T]) step(expired []T) []T {
+	w.current++
+	for l, span := range w.spans {
+		if w.current%span == 0 {
+			w.rotate(l)
+		}
+	}
+	for l := len(w.levels) - 1; l > 0; l-- {
+		if w.current%w.spans[l] == 0 {
+			slot, _ := w.levels[l].First()
+			w.cascade(slot)
+		}
+	}
+	slot, _ := w.levels[0].First()
+	return w.expire(slot, expired)
+}
+
+// Len returns the number of pending timers
+func (w *Wheel[T]) Len() int { return w.len }
+
+// place puts the timer in the lowest level whose slots reach its expiry
+func (w *Wheel[T]) place(t *Timer[T]) {
+	top := len(w.levels) - 1
+	for l, span := range w.spans {
+		offset := t.expiry/span - w.current/span
+		if offset < int64(w.size) || l == top {
+			offset = min(offset, int64(w.size)-1)
+			t.slot, _ = w.levels[l].Get(int(offset))
+			t.elem = t.slot.PushBack(t)
+			return
+		}
+	}
+}
+
+// rotate advances level l by one slot, the slot of the current time becomes the last one
+func (w *Wheel[T]) rotate(l int) {
+	slot, _ := w.levels[l].Dequeue()
+	w.levels[l].Enqueue(slot)
+}
+
+// expire collects the timers of the current level 0 slot, except those parked there
+// beyond the reach of a single level wheel, which are placed again
+func (w *Wheel[T]) expire(slot *list.List[*Timer[T]], expired []T) []T {
+	for e := slot.Front(); e != nil; {
+		next := e.Next()
+		t := slot.Remove(e)
+		if t.expiry > w.current {
+			w.place(t)
+		} else {
+			t.slot, t.elem = nil, nil
+			expired = append(expired, t.value)
+			w.len--
+		}
+		e = next
+	}
+	return expired
+}
+
+// cascade places the timers of a higher level slot again, closer to their expiry
+func (w *Wheel[T]) cascade(slot *list.List[*Timer[T]]) {
+	for e := slot.Front(); e != nil; {
+		next := e.Next()
+		t := slot.Remove(e)
+		w.place(t)
+		e = next
+	}
+}
@@ -0,0 +1,156 @@
+package timingwheel
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestWheel(t *testing.T) {
+	w := New(start, WithTick[string](time.Second), WithWheelSize[string](4), WithLevels[string](3))
+	w.AddAfter("c", 3*time.Second)
+	w.AddAfter("a", time.Second)
+	w.AddAfter("b", 1500*time.Millisecond)
+	far := w.AddAfter("far", 40*time.Second)
+	w.AddAfter("d", 20*time.Second)
+	if actualValue := w.Len(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := w.Tick(start.Add(999 * time.Millisecond)); len(actualValue) != 0 {
+		t.Errorf("Got %v expected nothing", actualValue)
+	}
+	assertValues(t, w.Tick(start.Add(3*time.Second)), "a", "b", "c")
+	if !w.Cancel(far) || w.Cancel(far) {
+		t.Error("unexpected Cancel result")
+	}
+	assertValues(t, w.Tick(start.Add(19*time.Second)))
+	assertValues(t, w.Tick(start.Add(time.Minute)), "d")
+	if actualValue := w.Len(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := w.Now(); !actualValue.Equal(start.Add(time.Minute)) {
+		t.Errorf("Got %v expected %v", actualValue, start.Add(time.Minute))
+	}
+}
+
+func TestPastDeadline(t *testing.T) {
+	w := New(start, WithTick[int](time.Second))
+	w.Tick(start.Add(10 * time.Second))
+	w.Add(1, start)
+	assertValues(t, w.Tick(start.Add(10*time.Second)))
+	assertValues(t, w.Tick(start.Add(11*time.Second)), 1)
+}
+
+func TestBeyondTopLevel(t *testing.T) {
+	w := New(start, WithTick[int](time.Second), WithWheelSize[int](4), WithLevels[int](1))
+	w.AddAfter(1, 10*time.Second)
+	assertValues(t, w.Tick(start.Add(9*time.Second)))
+	assertValues(t, w.Tick(start.Add(10*time.Second)), 1)
+}
+
+func TestLargeJump(t *testing.T) {
+	w := New[string](start)
+	w.AddAfter("day", 24*time.Hour)
+	w.AddAfter("hour", time.Hour)
+	w.AddAfter("week", 7*24*time.Hour)
+	begin := time.Now()
+	assertValues(t, w.Tick(start.Add(23*time.Hour)), "hour")
+	assertValues(t, w.Tick(start.Add(24*time.Hour-time.Millisecond)))
+	assertValues(t, w.Tick(start.Add(24*time.Hour)), "day")
+	assertValues(t, w.Tick(start.Add(30*24*time.Hour)), "week")
+	// stepping tick by tick would take billions of iterations
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Errorf("Tick took %v", elapsed)
+	}
+	if actualValue := w.Len(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestCancelExpired(t *testing.T) {
+	w := New(start, WithTick[int](time.Second))
+	timer := w.AddAfter(1, time.Second)
+	w.Tick(start.Add(time.Second))
+	if w.Cancel(timer) || timer.Value() != 1 {
+		t.Error("expected an expired timer not to be cancelled")
+	}
+}
+
+func TestCancelForeign(t *testing.T) {
+	a := New(start, WithTick[int](time.Second))
+	b := New(start, WithTick[int](time.Second))
+	a.AddAfter(1, time.Second)
+	timer := b.AddAfter(2, time.Second)
+	if a.Cancel(timer) {
+		t.Error("expected a timer of another wheel not to be cancelled")
+	}
+	if a.Len() != 1 || b.Len() != 1 {
+		t.Errorf("Got %v and %v expected 1 and 1", a.Len(), b.Len())
+	}
+	if actualValue, expectedValue := b.Tick(start.Add(time.Second)), []int{2}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for _, levels := range []int{1, 2, 3} {
+		testRandom(t, levels)
+	}
+}
+
+func testRandom(t *testing.T, levels int) {
+	r := rand.New(rand.NewSource(int64(levels)))
+	tick := time.Millisecond
+	w := New(start, WithTick[int](tick), WithWheelSize[int](8), WithLevels[int](levels))
+	deadlines := map[int]time.Time{}
+	timers := map[int]*Timer[int]{}
+	now := start
+	for i := 0; i < 5000; i++ {
+		switch r.Intn(4) {
+		case 0, 1:
+			deadline := now.Add(time.Duration(r.Intn(2000)) * tick / 3)
+			timers[i] = w.Add(i, deadline)
+			deadlines[i] = deadline
+		case 2:
+			for id, timer := range timers {
+				if !w.Cancel(timer) {
+					t.Fatalf("expected timer %v to be cancelled", id)
+				}
+				delete(timers, id)
+				delete(deadlines, id)
+				break
+			}
+		case 3:
+			now = now.Add(time.Duration(r.Intn(50)) * tick)
+			actual := w.Tick(now)
+			var expected []int
+			for id, deadline := range deadlines {
+				if !deadline.After(now) {
+					expected = append(expected, id)
+				}
+			}
+			for _, id := range expected {
+				delete(deadlines, id)
+				delete(timers, id)
+			}
+			slices.Sort(actual)
+			slices.Sort(expected)
+			if !slices.Equal(actual, expected) {
+				t.Fatalf("at %v got %v expected %v", now.Sub(start), actual, expected)
+			}
+		}
+		if w.Len() != len(timers) {
+			t.Fatalf("Got %v expected %v", w.Len(), len(timers))
+		}
+	}
+}
+
+func assertValues[T comparable](t *testing.T, actual []T, expected ...T) {
+	t.Helper()
+	if !slices.Equal(actual, expected) {
+		t.Errorf("Got %v expected %v", actual, expected)
+	}
+}
@@ -0,0 +1,27 @@
+package topk
+
+import (
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+)
+
+var _ dsgo.Container[int] = (*Heap[int])(nil)
+
+// Len returns the number of items kept.
+// The complexity is O(1)
+func (h *Heap[T]) Len() int { return h.heap.Len() }
+
+// Empty returns if the heap is empty.
+// The complexity is O(1)
+func (h *Heap[T]) Empty() bool { return h.Len() == 0 }
+
+// Values returns the items kept best first, leaving the heap unchanged
+func (h *Heap[T]) Values() []T {
+	res := h.heap.Values()
+	slices.Reverse(res)
+	return res
+}
+
+// Clear removes all items
+func (h *Heap[T]) Clear() { h.heap.Clear() }
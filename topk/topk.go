@@ -0,0 +1,127 @@
+// Package topk keeps the best k items of a stream in a bounded heap.
+//
+// The heap holds at most k items with the worst of them on top,
+// so a new item is compared with the worst one only, and once the heap is full it is admitted
+// only if it is better, evicting the worst. Selecting k items out of n this way is O(n log k).
+package topk
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/zrcoder/dsgo"
+	"github.com/zrcoder/dsgo/heap"
+)
+
+// Heap keeps the best k items pushed
+type Heap[T any] struct {
+	k    int
+	cmp  dsgo.Comparator[T]
+	heap *heap.Heap[T] // the worst item on top
+}
+
+// New creates a heap keeping the k largest items
+func New[T cmp.Ordered](k int) *Heap[T] {
+	return NewWith(k, dsgo.Reverse(cmp.Compare[T]))
+}
+
+// NewWith creates a heap keeping the k items that sort first by cmp,
+// i.e. a is better than b if cmp(a, b) < 0
+// The heap grows as items are pushed, so a large k costs nothing up front.
+func NewWith[T any](k int, cmp dsgo.Comparator[T]) *Heap[T] {
+	return newHeap(k, cmp, 0)
+}
+
+// newHeap creates a heap with room for capacity items preallocated
+func newHeap[T any](k int, cmp dsgo.Comparator[T], capacity int) *Heap[T] {
+	if k < 1 {
+		panic("topk: k must be at least 1")
+	}
+	return &Heap[T]{
+		k:    k,
+		cmp:  cmp,
+		heap: heap.NewWith(dsgo.Reverse(cmp), heap.WithCapacity[T](min(k, capacity))),
+	}
+}
+
+// Push offers value to the heap, it returns false if value is rejected.
+// Once the heap is full, value is admitted only if it is better than the worst item, which is evicted.
+// The complexity is O(log k).
+func (h *Heap[T]) Push(value T) bool {
+	if h.heap.Len() < h.k {
+		h.heap.Push(value)
+		return true
+	}
+	worst, _ := h.heap.Peek()
+	if h.cmp(value, worst) >= 0 {
+		return false
+	}
+	h.heap.Pop()
+	h.heap.Push(value)
+	return true
+}
+
+// Worst returns the worst item kept, the one the next admitted item would evict when full.
+// The complexity is O(1).
+func (h *Heap[T]) Worst() (value T, ok bool) {
+	return h.heap.Peek()
+}
+
+// Cap returns k, the maximum number of items kept
+func (h *Heap[T]) Cap() int { return h.k }
+
+// Full returns true if the heap holds k items
+func (h *Heap[T]) Full() bool { return h.heap.Len() == h.k }
+
+// Drain removes all items and returns them best first.
+// The complexity is O(k log k).
+func (h *Heap[T]) Drain() []T {
+	res := make([]T, h.heap.Len())
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i], _ = h.heap.Pop()
+	}
+	return res
+}
+
+// TopK returns the k largest items of s, largest first, or none if k <= 0
+func TopK[S ~[]T, T cmp.Ordered](s S, k int) []T {
+	return TopKFunc(s, k, cmp.Compare[T])
+}
+
+// BottomK returns the k smallest items of s, smallest first, or none if k <= 0
+func BottomK[S ~[]T, T cmp.Ordered](s S, k int) []T {
+	return BottomKFunc(s, k, cmp.Compare[T])
+}
+
+// TopKFunc returns the k largest items of s by cmp, largest first, or none if k <= 0
+func TopKFunc[S ~[]T, T any](s S, k int, cmp dsgo.Comparator[T]) []T {
+	return collect(slices.Values(s), k, dsgo.Reverse(cmp), len(s))
+}
+
+// BottomKFunc returns the k smallest items of s by cmp, smallest first, or none if k <= 0
+func BottomKFunc[S ~[]T, T any](s S, k int, cmp dsgo.Comparator[T]) []T {
+	return collect(slices.Values(s), k, cmp, len(s))
+}
+
+// TopKSeq returns the k largest items of seq, largest first, or none if k <= 0
+func TopKSeq[T cmp.Ordered](seq iter.Seq[T], k int) []T {
+	return collect(seq, k, dsgo.Reverse(cmp.Compare[T]), 0)
+}
+
+// BottomKSeq returns the k smallest items of seq, smallest first, or none if k <= 0
+func BottomKSeq[T cmp.Ordered](seq iter.Seq[T], k int) []T {
+	return collect(seq, k, cmp.Compare[T], 0)
+}
+
+// collect keeps the k items of seq that sort first by cmp, with capacity items preallocated
+func collect[T any](seq iter.Seq[T], k int, cmp dsgo.Comparator[T], capacity int) []T {
+	if k <= 0 {
+		return []T{}
+	}
+	h := newHeap(k, cmp, capacity)
+	for v := range seq {
+		h.Push(v)
+	}
+	return h.Drain()
+}
@@ -0,0 +1,132 @@
+package topk
+
+import (
+	"cmp"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestHeap(t *testing.T) {
+	h := New[int](3)
+	for _, v := range []int{5, 1, 9} {
+		if !h.Push(v) {
+			t.Errorf("expected %v to be admitted", v)
+		}
+	}
+	if !h.Full() || h.Cap() != 3 {
+		t.Errorf("Got len %v cap %v", h.Len(), h.Cap())
+	}
+	if worst, ok := h.Worst(); worst != 1 || !ok {
+		t.Errorf("Got %v expected %v", worst, 1)
+	}
+	if h.Push(1) || h.Push(0) {
+		t.Error("expected items no better than the worst to be rejected")
+	}
+	if !h.Push(7) {
+		t.Error("expected 7 to be admitted")
+	}
+	if actualValue, expectedValue := h.Values(), []int{9, 7, 5}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := h.Drain(), []int{9, 7, 5}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if !h.Empty() {
+		t.Error("expected an empty heap after Drain")
+	}
+	if _, ok := h.Worst(); ok {
+		t.Error("expected no worst item")
+	}
+	h.Push(1)
+	h.Clear()
+	if !h.Empty() {
+		t.Error("expected an empty heap after Clear")
+	}
+}
+
+type task struct {
+	name    string
+	latency int
+}
+
+func TestNewWith(t *testing.T) {
+	h := NewWith(2, func(a, b task) int { return cmp.Compare(a.latency, b.latency) })
+	for _, v := range []task{{"a", 30}, {"b", 10}, {"c", 20}, {"d", 5}} {
+		h.Push(v)
+	}
+	if actualValue, expectedValue := h.Drain(), []task{{"d", 5}, {"b", 10}}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = r.Intn(500)
+	}
+	sorted := slices.Sorted(slices.Values(nums))
+	descending := slices.Clone(sorted)
+	slices.Reverse(descending)
+
+	if actualValue := TopK(nums, 10); !slices.Equal(actualValue, descending[:10]) {
+		t.Errorf("Got %v expected %v", actualValue, descending[:10])
+	}
+	if actualValue := BottomK(nums, 10); !slices.Equal(actualValue, sorted[:10]) {
+		t.Errorf("Got %v expected %v", actualValue, sorted[:10])
+	}
+	if actualValue := TopKFunc(nums, 5, cmp.Compare[int]); !slices.Equal(actualValue, descending[:5]) {
+		t.Errorf("Got %v expected %v", actualValue, descending[:5])
+	}
+	if actualValue := BottomKFunc(nums, 5, cmp.Compare[int]); !slices.Equal(actualValue, sorted[:5]) {
+		t.Errorf("Got %v expected %v", actualValue, sorted[:5])
+	}
+	if actualValue, expectedValue := TopK([]int{2, 8, 5}, 10), []int{8, 5, 2}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	counts := map[string]int{"a": 3, "b": 7, "c": 5}
+	if actualValue, expectedValue := TopKSeq(maps.Values(counts), 2), []int{7, 5}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := BottomKSeq(maps.Keys(counts), 2), []string{"a", "b"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestHelpersK(t *testing.T) {
+	nums := []int{1, 2, 3}
+	for _, k := range []int{0, -1} {
+		if actualValue := TopK(nums, k); len(actualValue) != 0 {
+			t.Errorf("Got %v expected nothing for k = %v", actualValue, k)
+		}
+		if actualValue := BottomKSeq(slices.Values(nums), k); len(actualValue) != 0 {
+			t.Errorf("Got %v expected nothing for k = %v", actualValue, k)
+		}
+	}
+	if actualValue, expectedValue := TopK(nums, 1<<62), []int{3, 2, 1}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := BottomKSeq(slices.Values(nums), 1<<62), []int{1, 2, 3}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	h := NewWith(1<<62, cmp.Compare[int])
+	h.Push(1)
+	if actualValue := h.Full(); actualValue {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func BenchmarkTopK(b *testing.B) {
+	r := rand.New(rand.NewSource(3))
+	nums := make([]int, 100000)
+	for i := range nums {
+		nums[i] = r.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		TopK(nums, 100)
+	}
+}
@@ -0,0 +1,127 @@
+package unionfind
+
+import "slices"
+
+// Keyed is a union-find over arbitrary comparable elements.
+// Elements are added lazily the first time they are used,
+// and each component keeps its members so they can be enumerated.
+type Keyed[T comparable] struct {
+	uf       *UnionFind
+	ids      map[T]int
+	elements []T   // elements by id, in insertion order
+	next     []int // members of a component form a circular list through next
+}
+
+func NewKeyed[T comparable]() *Keyed[T] {
+	return &Keyed[T]{
+		uf:  NewUnionFind(0),
+		ids: make(map[T]int),
+	}
+}
+
+// Add adds x as a singleton component, it returns false if x is already present
+func (k *Keyed[T]) Add(x T) bool {
+	if _, ok := k.ids[x]; ok {
+		return false
+	}
+	k.id(x)
+	return true
+}
+
+// Contains returns true if x has been added
+func (k *Keyed[T]) Contains(x T) bool {
+	_, ok := k.ids[x]
+	return ok
+}
+
+// Find returns the representative of x's component
+func (k *Keyed[T]) Find(x T) T {
+	return k.elements[k.uf.Find(k.id(x))]
+}
+
+// Join merges the components of x and y, it returns false if they are already connected
+func (k *Keyed[T]) Join(x, y T) bool {
+	i, j := k.id(x), k.id(y)
+	if !k.uf.Join(i, j) {
+		return false
+	}
+	// splice the two circular member lists into one
+	k.next[i], k.next[j] = k.next[j], k.next[i]
+	return true
+}
+
+// Connected returns true if x and y are in the same component,
+// elements never added are only connected to themselves
+func (k *Keyed[T]) Connected(x, y T) bool {
+	i, ok1 := k.ids[x]
+	j, ok2 := k.ids[y]
+	if !ok1 || !ok2 {
+		return x == y
+	}
+	return k.uf.Connected(i, j)
+}
+
+// Count returns the number of components
+func (k *Keyed[T]) Count() int { return k.uf.Count() }
+
+// Size returns the number of elements in x's component, 0 if x has never been added
+func (k *Keyed[T]) Size(x T) int {
+	i, ok := k.ids[x]
+	if !ok {
+		return 0
+	}
+	return k.uf.Size(i)
+}
+
+// Len returns the number of elements
+func (k *Keyed[T]) Len() int { return len(k.elements) }
+
+// Members returns the elements in x's component in insertion order, nil if x has never been added.
+// The complexity is O(s log s) where s is the size of the component.
+func (k *Keyed[T]) Members(x T) []T {
+	i, ok := k.ids[x]
+	if !ok {
+		return nil
+	}
+	return k.members(i)
+}
+
+// Groups returns all components, each in insertion order,
+// ordered by their earliest inserted element
+func (k *Keyed[T]) Groups() [][]T {
+	res := make([][]T, 0, k.Count())
+	seen := make([]bool, len(k.elements))
+	for i := range k.elements {
+		if seen[k.uf.Find(i)] {
+			continue
+		}
+		seen[k.uf.Find(i)] = true
+		res = append(res, k.members(i))
+	}
+	return res
+}
+
+func (k *Keyed[T]) members(i int) []T {
+	ids := []int{i}
+	for j := k.next[i]; j != i; j = k.next[j] {
+		ids = append(ids, j)
+	}
+	slices.Sort(ids)
+	res := make([]T, len(ids))
+	for n, id := range ids {
+		res[n] = k.elements[id]
+	}
+	return res
+}
+
+// id returns the id of x, adding x if needed
+func (k *Keyed[T]) id(x T) int {
+	if i, ok := k.ids[x]; ok {
+		return i
+	}
+	i := k.uf.add()
+	k.ids[x] = i
+	k.elements = append(k.elements, x)
+	k.next = append(k.next, i)
+	return i
+}
@@ -0,0 +1,87 @@
+package unionfind
+
+// RollbackUnionFind is a union-find over the elements 0..n-1 whose joins can be undone,
+// as needed by offline algorithms such as dynamic connectivity over a segment tree of time.
+// It uses union by size without path compression, so Find is O(log n) and every join
+// changes a single link that is recorded in a history stack.
+type RollbackUnionFind struct {
+	parent  []int
+	size    []int
+	count   int
+	history []int // roots linked under another root, in join order
+}
+
+func NewRollbackUnionFind(n int) *RollbackUnionFind {
+	uf := &RollbackUnionFind{
+		parent: make([]int, n),
+		size:   make([]int, n),
+		count:  n,
+	}
+	for i := range uf.parent {
+		uf.parent[i] = i
+		uf.size[i] = 1
+	}
+	return uf
+}
+
+// Find returns the root of x's component.
+// The complexity is O(log n).
+func (uf *RollbackUnionFind) Find(x int) int {
+	for uf.parent[x] != x {
+		x = uf.parent[x]
+	}
+	return x
+}
+
+// Join merges the components of x and y, it returns false if they are already connected.
+// Only joins that merge are recorded in the history.
+// The complexity is O(log n).
+func (uf *RollbackUnionFind) Join(x, y int) bool {
+	rootX, rootY := uf.Find(x), uf.Find(y)
+	if rootX == rootY {
+		return false
+	}
+	if uf.size[rootX] > uf.size[rootY] {
+		rootX, rootY = rootY, rootX
+	}
+	uf.parent[rootX] = rootY
+	uf.size[rootY] += uf.size[rootX]
+	uf.count--
+	uf.history = append(uf.history, rootX)
+	return true
+}
+
+// Connected returns true if x and y are in the same component
+func (uf *RollbackUnionFind) Connected(x, y int) bool {
+	return uf.Find(x) == uf.Find(y)
+}
+
+// Count returns the number of components
+func (uf *RollbackUnionFind) Count() int { return uf.count }
+
+// Size returns the number of elements in x's component
+func (uf *RollbackUnionFind) Size(x int) int {
+	return uf.size[uf.Find(x)]
+}
+
+// Len returns the number of elements
+func (uf *RollbackUnionFind) Len() int { return len(uf.parent) }
+
+// Snapshot returns a version that Rollback can return to
+func (uf *RollbackUnionFind) Snapshot() int { return len(uf.history) }
+
+// Rollback undoes the joins made after the snapshot to was taken.
+// The complexity is O(1) per undone join.
+func (uf *RollbackUnionFind) Rollback(to int) {
+	if to < 0 || to > len(uf.history) {
+		panic("unionfind: invalid snapshot")
+	}
+	for len(uf.history) > to {
+		x := uf.history[len(uf.history)-1]
+		uf.history = uf.history[:len(uf.history)-1]
+		root := uf.parent[x]
+		uf.size[root] -= uf.size[x]
+		uf.parent[x] = x
+		uf.count++
+	}
+}
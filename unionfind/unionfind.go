@@ -1,23 +1,71 @@
 package unionfind
 
-type UnionFind []int
+// UnionFind is a disjoint-set forest over the elements 0..n-1,
+// with path compression and union by size.
+type UnionFind struct {
+	parent []int
+	size   []int // size of the component, only valid for roots
+	count  int
+}
 
-func NewUnionFind(n int) UnionFind {
-	unionFind := make([]int, n)
-	for i := range unionFind {
-		unionFind[i] = i
+func NewUnionFind(n int) *UnionFind {
+	uf := &UnionFind{
+		parent: make([]int, n),
+		size:   make([]int, n),
+		count:  n,
+	}
+	for i := range uf.parent {
+		uf.parent[i] = i
+		uf.size[i] = 1
 	}
-	return unionFind
+	return uf
 }
 
-func (uf UnionFind) Find(x int) int {
-	for uf[x] != x {
-		x, uf[x] = uf[x], uf[uf[x]]
+// Find returns the root of x's component
+func (uf *UnionFind) Find(x int) int {
+	for uf.parent[x] != x {
+		x, uf.parent[x] = uf.parent[x], uf.parent[uf.parent[x]]
 	}
 	return x
 }
 
-func (uf UnionFind) Join(x, y int) {
+// Join merges the components of x and y, the smaller one is linked under the larger one.
+// It returns false if x and y are already connected.
+func (uf *UnionFind) Join(x, y int) bool {
 	rootX, rootY := uf.Find(x), uf.Find(y)
-	uf[rootX] = rootY
+	if rootX == rootY {
+		return false
+	}
+	if uf.size[rootX] > uf.size[rootY] {
+		rootX, rootY = rootY, rootX
+	}
+	uf.parent[rootX] = rootY
+	uf.size[rootY] += uf.size[rootX]
+	uf.count--
+	return true
+}
+
+// Connected returns true if x and y are in the same component
+func (uf *UnionFind) Connected(x, y int) bool {
+	return uf.Find(x) == uf.Find(y)
+}
+
+// Count returns the number of components
+func (uf *UnionFind) Count() int { return uf.count }
+
+// Size returns the number of elements in x's component
+func (uf *UnionFind) Size(x int) int {
+	return uf.size[uf.Find(x)]
+}
+
+// Len returns the number of elements
+func (uf *UnionFind) Len() int { return len(uf.parent) }
+
+// add adds a new singleton element and returns it
+func (uf *UnionFind) add() int {
+	x := len(uf.parent)
+	uf.parent = append(uf.parent, x)
+	uf.size = append(uf.size, 1)
+	uf.count++
+	return x
 }
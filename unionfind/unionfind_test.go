@@ -0,0 +1,207 @@
+package unionfind
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	uf := NewUnionFind(10)
+	if uf.Count() != 10 || uf.Len() != 10 {
+		t.Errorf("Got %v expected %v", uf.Count(), 10)
+	}
+	if !uf.Join(2, 5) || !uf.Join(5, 8) || !uf.Join(1, 4) || !uf.Join(1, 7) || !uf.Join(9, 4) {
+		t.Error("expected all joins to merge")
+	}
+	if uf.Join(2, 8) {
+		t.Error("expected 2 and 8 to be connected already")
+	}
+	if actualValue := uf.Count(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if !uf.Connected(9, 7) || uf.Connected(9, 5) {
+		t.Error("unexpected connectivity")
+	}
+	if actualValue := uf.Size(8); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue := uf.Size(9); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := uf.Size(0); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+
+	uf.Join(9, 5)
+	if actualValue := uf.Size(2); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue := uf.Count(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if root := uf.Find(2); root != uf.Find(1) {
+		t.Errorf("Got root %v expected %v", root, uf.Find(1))
+	}
+}
+
+func TestUnionBySize(t *testing.T) {
+	const n = 1 << 10
+	uf := NewUnionFind(n)
+	// always joining the big component under a new singleton degrades without union by size
+	for i := 1; i < n; i++ {
+		uf.Join(i-1, i)
+	}
+	root := uf.Find(0)
+	for i := range uf.parent {
+		depth := 0
+		for x := i; x != root; x = uf.parent[x] {
+			depth++
+		}
+		if depth > 1 {
+			t.Fatalf("depth of %d is %d", i, depth)
+		}
+	}
+	if uf.Count() != 1 || uf.Size(n-1) != n {
+		t.Errorf("Got count %v size %v", uf.Count(), uf.Size(n-1))
+	}
+}
+
+func BenchmarkJoin(b *testing.B) {
+	uf := NewUnionFind(b.N + 1)
+	b.ResetTimer()
+	for i := 1; i <= b.N; i++ {
+		uf.Join(i-1, i)
+	}
+}
+
+func TestKeyed(t *testing.T) {
+	uf := NewKeyed[string]()
+	if !uf.Add("carol") || uf.Add("carol") {
+		t.Error("unexpected Add result")
+	}
+	uf.Join("alice", "bob")
+	uf.Join("dave", "erin")
+	uf.Join("bob", "erin")
+	uf.Join("frank", "grace")
+	if uf.Join("alice", "dave") {
+		t.Error("expected alice and dave to be connected already")
+	}
+
+	if actualValue := uf.Len(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue := uf.Count(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if !uf.Connected("alice", "erin") || uf.Connected("alice", "frank") {
+		t.Error("unexpected connectivity")
+	}
+	if uf.Connected("nobody", "alice") || !uf.Connected("nobody", "nobody") || uf.Contains("nobody") {
+		t.Error("unexpected connectivity for unknown element")
+	}
+	if uf.Find("dave") != uf.Find("alice") {
+		t.Error("expected the same representative")
+	}
+	if actualValue := uf.Size("bob"); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := uf.Size("nobody"); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue, expectedValue := uf.Members("erin"), []string{"alice", "bob", "dave", "erin"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := uf.Members("nobody"); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	expectedGroups := [][]string{{"carol"}, {"alice", "bob", "dave", "erin"}, {"frank", "grace"}}
+	if actualValue := uf.Groups(); !slices.EqualFunc(actualValue, expectedGroups, slices.Equal[[]string]) {
+		t.Errorf("Got %v expected %v", actualValue, expectedGroups)
+	}
+}
+
+func TestRollbackUnionFind(t *testing.T) {
+	uf := NewRollbackUnionFind(6)
+	uf.Join(0, 1)
+	s1 := uf.Snapshot()
+	uf.Join(2, 3)
+	uf.Join(1, 3)
+	if uf.Join(0, 2) {
+		t.Error("expected 0 and 2 to be connected already")
+	}
+	s2 := uf.Snapshot()
+	uf.Join(4, 5)
+	uf.Join(5, 0)
+	if uf.Count() != 1 || uf.Size(4) != 6 {
+		t.Errorf("Got count %v size %v expected count 1 size 6", uf.Count(), uf.Size(4))
+	}
+
+	uf.Rollback(s2)
+	if uf.Count() != 3 || uf.Size(0) != 4 || uf.Connected(4, 5) || !uf.Connected(0, 3) {
+		t.Errorf("unexpected state after rollback: count %v size %v", uf.Count(), uf.Size(0))
+	}
+	uf.Rollback(s1)
+	if uf.Count() != 5 || uf.Size(0) != 2 || uf.Connected(1, 3) || uf.Connected(2, 3) {
+		t.Errorf("unexpected state after rollback: count %v size %v", uf.Count(), uf.Size(0))
+	}
+	uf.Rollback(0)
+	for i := 0; i < uf.Len(); i++ {
+		if uf.Find(i) != i || uf.Size(i) != 1 {
+			t.Errorf("expected %d to be a singleton", i)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for an invalid snapshot")
+		}
+	}()
+	uf.Rollback(1)
+}
+
+func TestWeighted(t *testing.T) {
+	uf := NewWeighted[int](6)
+	// a - b = 3, b - c = 4, d - c = 10
+	if !uf.Join(0, 1, 3) || !uf.Join(1, 2, 4) || !uf.Join(3, 2, 10) {
+		t.Error("expected consistent constraints")
+	}
+	if w, ok := uf.Diff(0, 3); !ok || w != -3 {
+		t.Errorf("Got %v expected %v", w, -3)
+	}
+	if w, ok := uf.Diff(3, 0); !ok || w != 3 {
+		t.Errorf("Got %v expected %v", w, 3)
+	}
+	if w, ok := uf.Diff(2, 2); !ok || w != 0 {
+		t.Errorf("Got %v expected %v", w, 0)
+	}
+	if _, ok := uf.Diff(0, 4); ok {
+		t.Error("expected unknown difference")
+	}
+	if !uf.Join(0, 2, 7) {
+		t.Error("expected a redundant consistent constraint")
+	}
+	if uf.Join(0, 2, 8) {
+		t.Error("expected a contradiction")
+	}
+	if w, _ := uf.Diff(0, 2); w != 7 {
+		t.Errorf("Got %v expected %v", w, 7)
+	}
+
+	uf.Join(4, 5, -2)
+	uf.Join(5, 1, 1)
+	if w, ok := uf.Diff(4, 3); !ok || w != -7 {
+		t.Errorf("Got %v expected %v", w, -7)
+	}
+	if uf.Count() != 1 || uf.Size(0) != 6 || !uf.Connected(4, 0) {
+		t.Errorf("Got count %v size %v", uf.Count(), uf.Size(0))
+	}
+}
+
+func TestWeightedFloat(t *testing.T) {
+	uf := NewWeighted[float64](3)
+	uf.Join(0, 1, 0.5)
+	uf.Join(1, 2, 0.25)
+	if w, ok := uf.Diff(2, 0); !ok || w != -0.75 {
+		t.Errorf("Got %v expected %v", w, -0.75)
+	}
+}
@@ -0,0 +1,85 @@
+package unionfind
+
+import "github.com/zrcoder/dsgo"
+
+// Weighted is a union-find over the elements 0..n-1 that also tracks differences between elements,
+// given as constraints "x - y = w". Each element stores its potential relative to its parent,
+// so the difference between any two connected elements is known.
+//
+// Differences are compared exactly, so with floating-point weights a chain of constraints
+// may be reported as contradictory because of rounding.
+type Weighted[W dsgo.Number] struct {
+	parent []int
+	size   []int
+	pot    []W // value(x) - value(parent[x])
+	count  int
+}
+
+func NewWeighted[W dsgo.Number](n int) *Weighted[W] {
+	uf := &Weighted[W]{
+		parent: make([]int, n),
+		size:   make([]int, n),
+		pot:    make([]W, n),
+		count:  n,
+	}
+	for i := range uf.parent {
+		uf.parent[i] = i
+		uf.size[i] = 1
+	}
+	return uf
+}
+
+// Find returns the root of x's component
+func (uf *Weighted[W]) Find(x int) int {
+	p := uf.parent[x]
+	if p == x {
+		return x
+	}
+	root := uf.Find(p)
+	uf.pot[x] += uf.pot[p]
+	uf.parent[x] = root
+	return root
+}
+
+// Join adds the constraint x - y = w.
+// It returns false and changes nothing if the constraint contradicts the known difference of x and y.
+func (uf *Weighted[W]) Join(x, y int, w W) bool {
+	rootX, rootY := uf.Find(x), uf.Find(y)
+	if rootX == rootY {
+		return uf.pot[x]-uf.pot[y] == w
+	}
+	// x = rootX + pot[x] and y = rootY + pot[y], so rootX - rootY = w - pot[x] + pot[y]
+	d := w - uf.pot[x] + uf.pot[y]
+	if uf.size[rootX] > uf.size[rootY] {
+		rootX, rootY, d = rootY, rootX, -d
+	}
+	uf.parent[rootX] = rootY
+	uf.pot[rootX] = d
+	uf.size[rootY] += uf.size[rootX]
+	uf.count--
+	return true
+}
+
+// Diff returns x - y, ok is false if x and y are not connected so the difference is unknown
+func (uf *Weighted[W]) Diff(x, y int) (w W, ok bool) {
+	if uf.Find(x) != uf.Find(y) {
+		return
+	}
+	return uf.pot[x] - uf.pot[y], true
+}
+
+// Connected returns true if x and y are in the same component
+func (uf *Weighted[W]) Connected(x, y int) bool {
+	return uf.Find(x) == uf.Find(y)
+}
+
+// Count returns the number of components
+func (uf *Weighted[W]) Count() int { return uf.count }
+
+// Size returns the number of elements in x's component
+func (uf *Weighted[W]) Size(x int) int {
+	return uf.size[uf.Find(x)]
+}
+
+// Len returns the number of elements
+func (uf *Weighted[W]) Len() int { return len(uf.parent) }
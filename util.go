@@ -9,3 +9,10 @@ type Pair[K comparable, V any] struct {
 	Key   K
 	Value V
 }
+
+// Number is a constraint that permits any integer or floating-point type
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}